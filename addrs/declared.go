@@ -0,0 +1,80 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+// IsDeclaredIn reports whether the object which the given address
+// (relative to the module) refers to is declared in the given module,
+// i.e. whether there is a matching resource block or module call.
+//
+// Instance keys are checked against the presence of count (IntKey)
+// and for_each (StringKey) in the matching resource block.
+//
+// Nested modules are not part of module.Meta, so for addresses
+// within module calls only the first module call is checked.
+func IsDeclaredIn(addr Targetable, meta *module.Meta) bool {
+	if meta == nil {
+		return false
+	}
+
+	switch a := addr.(type) {
+	case ModuleInstance:
+		return isModuleDeclaredIn(a, meta)
+	case AbsResource:
+		if !a.Module.IsRoot() {
+			return isModuleDeclaredIn(a.Module, meta)
+		}
+		_, ok := declaredResource(a.Resource, meta)
+		return ok
+	case AbsResourceInstance:
+		if !a.Module.IsRoot() {
+			return isModuleDeclaredIn(a.Module, meta)
+		}
+		r, ok := declaredResource(a.Resource.Resource, meta)
+		if !ok {
+			return false
+		}
+		return instanceKeyMatches(a.Resource.Key, r.HasCount, r.HasForEach)
+	}
+
+	return false
+}
+
+func isModuleDeclaredIn(addr ModuleInstance, meta *module.Meta) bool {
+	if addr.IsRoot() {
+		return true
+	}
+	_, ok := meta.ModuleCalls[addr[0].Name]
+	return ok
+}
+
+func declaredResource(addr Resource, meta *module.Meta) (module.Resource, bool) {
+	var resources map[string]module.Resource
+	switch addr.Mode {
+	case ManagedResourceMode:
+		resources = meta.Resources
+	case DataResourceMode:
+		resources = meta.DataSources
+	case EphemeralResourceMode:
+		resources = meta.EphemeralResources
+	}
+
+	r, ok := resources[addr.String()]
+	return r, ok
+}
+
+func instanceKeyMatches(key InstanceKey, hasCount, hasForEach bool) bool {
+	switch key.(type) {
+	case IntKey:
+		return hasCount
+	case StringKey:
+		return hasForEach
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+func TestIsDeclaredIn(t *testing.T) {
+	meta := &module.Meta{
+		Resources: map[string]module.Resource{
+			"aws_instance.counted": {
+				Type:     "aws_instance",
+				Name:     "counted",
+				HasCount: true,
+			},
+			"aws_instance.single": {
+				Type: "aws_instance",
+				Name: "single",
+			},
+		},
+		DataSources: map[string]module.Resource{
+			"data.aws_ami.each": {
+				Type:       "aws_ami",
+				Name:       "each",
+				HasForEach: true,
+			},
+		},
+		EphemeralResources: map[string]module.Resource{},
+		ModuleCalls: map[string]module.DeclaredModuleCall{
+			"net": {
+				LocalName: "net",
+			},
+		},
+	}
+
+	testCases := []struct {
+		addr     string
+		expected bool
+	}{
+		{"aws_instance.counted", true},
+		{"aws_instance.counted[1]", true},
+		{`aws_instance.counted["a"]`, false},
+		{"aws_instance.single", true},
+		{"aws_instance.single[0]", false},
+		{"aws_instance.missing", false},
+		{`data.aws_ami.each["a"]`, true},
+		{"aws_ami.each", false},
+		{"ephemeral.aws_ami.each", false},
+		{"module.net", true},
+		{"module.net.aws_instance.anything", true},
+		{"module.missing", false},
+		{"module.missing.aws_instance.single", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.addr, func(t *testing.T) {
+			target, diags := ParseTargetStr(tc.addr)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			declared := IsDeclaredIn(target.Subject, meta)
+			if declared != tc.expected {
+				t.Fatalf("expected %q declared: %t, given: %t", tc.addr, tc.expected, declared)
+			}
+		})
+	}
+}
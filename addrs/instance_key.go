@@ -0,0 +1,73 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// InstanceKey represents the key of an instance of a resource or module
+// call which uses count (IntKey) or for_each (StringKey)
+type InstanceKey interface {
+	String() string
+	instanceKey()
+}
+
+// NoKey represents the absence of an instance key
+var NoKey InstanceKey
+
+// IntKey is the key of an instance created by the count meta-argument
+type IntKey int
+
+func (k IntKey) instanceKey() {}
+
+func (k IntKey) String() string {
+	return fmt.Sprintf("[%d]", int(k))
+}
+
+// StringKey is the key of an instance created by the for_each meta-argument
+type StringKey string
+
+func (k StringKey) instanceKey() {}
+
+func (k StringKey) String() string {
+	return fmt.Sprintf("[%s]", strconv.Quote(string(k)))
+}
+
+// ParseInstanceKey converts the given value into an instance key,
+// which must be either a string or a whole number
+func ParseInstanceKey(key cty.Value) (InstanceKey, error) {
+	if !key.IsKnown() || key.IsNull() {
+		return NoKey, fmt.Errorf("instance key must be known and not null")
+	}
+
+	switch key.Type() {
+	case cty.String:
+		return StringKey(key.AsString()), nil
+	case cty.Number:
+		bf := key.AsBigFloat()
+		if !bf.IsInt() {
+			return NoKey, fmt.Errorf("numeric instance key must be a whole number")
+		}
+		i, accuracy := bf.Int64()
+		if accuracy != 0 || i < 0 {
+			return NoKey, fmt.Errorf("numeric instance key must be a non-negative whole number")
+		}
+		return IntKey(i), nil
+	default:
+		return NoKey, fmt.Errorf("instance key must be either a string or a number")
+	}
+}
+
+func instanceKeyString(key InstanceKey) string {
+	if key == NoKey {
+		return ""
+	}
+	return key.String()
+}
@@ -0,0 +1,77 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"strings"
+)
+
+// ModuleInstanceStep represents a single module call
+// within a module instance address, e.g. module.foo["a"]
+type ModuleInstanceStep struct {
+	Name        string
+	InstanceKey InstanceKey
+}
+
+func (s ModuleInstanceStep) String() string {
+	return "module." + s.Name + instanceKeyString(s.InstanceKey)
+}
+
+// ModuleInstance is an address of a module instance,
+// e.g. module.net["a"].module.subnets[0]
+//
+// An empty ModuleInstance represents the root module.
+type ModuleInstance []ModuleInstanceStep
+
+// RootModuleInstance is the address of the root module
+var RootModuleInstance = ModuleInstance{}
+
+func (m ModuleInstance) IsRoot() bool {
+	return len(m) == 0
+}
+
+func (m ModuleInstance) String() string {
+	steps := make([]string, 0, len(m))
+	for _, step := range m {
+		steps = append(steps, step.String())
+	}
+	return strings.Join(steps, ".")
+}
+
+// Module returns the static module path, i.e. the address without instance keys
+func (m ModuleInstance) Module() Module {
+	mod := make(Module, 0, len(m))
+	for _, step := range m {
+		mod = append(mod, step.Name)
+	}
+	return mod
+}
+
+func (m ModuleInstance) Equal(other ModuleInstance) bool {
+	if len(m) != len(other) {
+		return false
+	}
+	for i := range m {
+		if m[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m ModuleInstance) targetable() {}
+
+// Module is a static module path, i.e. a list of module call names
+// from the root module, which does not distinguish instances
+type Module []string
+
+func (m Module) String() string {
+	steps := make([]string, 0, len(m))
+	for _, name := range m {
+		steps = append(steps, "module."+name)
+	}
+	return strings.Join(steps, ".")
+}
@@ -0,0 +1,96 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+// ResourceMode distinguishes managed resources,
+// data sources and ephemeral resources
+type ResourceMode rune
+
+const (
+	InvalidResourceMode   ResourceMode = 0
+	ManagedResourceMode   ResourceMode = 'M'
+	DataResourceMode      ResourceMode = 'D'
+	EphemeralResourceMode ResourceMode = 'E'
+)
+
+// Resource is an address of a resource relative to its module,
+// e.g. aws_instance.foo or data.aws_ami.foo
+type Resource struct {
+	Mode ResourceMode
+	Type string
+	Name string
+}
+
+func (r Resource) String() string {
+	switch r.Mode {
+	case DataResourceMode:
+		return "data." + r.Type + "." + r.Name
+	case EphemeralResourceMode:
+		return "ephemeral." + r.Type + "." + r.Name
+	default:
+		return r.Type + "." + r.Name
+	}
+}
+
+// Instance returns the address of the instance with the given key
+func (r Resource) Instance(key InstanceKey) ResourceInstance {
+	return ResourceInstance{
+		Resource: r,
+		Key:      key,
+	}
+}
+
+// ResourceInstance is an address of a resource instance relative
+// to its module, e.g. aws_instance.foo[0]
+type ResourceInstance struct {
+	Resource Resource
+	Key      InstanceKey
+}
+
+func (r ResourceInstance) String() string {
+	return r.Resource.String() + instanceKeyString(r.Key)
+}
+
+// AbsResource is an absolute address of a resource,
+// e.g. module.foo.aws_instance.bar
+type AbsResource struct {
+	Module   ModuleInstance
+	Resource Resource
+}
+
+func (r AbsResource) String() string {
+	if r.Module.IsRoot() {
+		return r.Resource.String()
+	}
+	return r.Module.String() + "." + r.Resource.String()
+}
+
+func (r AbsResource) targetable() {}
+
+// AbsResourceInstance is an absolute address of a resource instance,
+// e.g. module.foo["a"].aws_instance.bar[0]
+type AbsResourceInstance struct {
+	Module   ModuleInstance
+	Resource ResourceInstance
+}
+
+func (r AbsResourceInstance) String() string {
+	if r.Module.IsRoot() {
+		return r.Resource.String()
+	}
+	return r.Module.String() + "." + r.Resource.String()
+}
+
+// ContainingResource returns the address of the resource
+// which the instance belongs to
+func (r AbsResourceInstance) ContainingResource() AbsResource {
+	return AbsResource{
+		Module:   r.Module,
+		Resource: r.Resource.Resource,
+	}
+}
+
+func (r AbsResourceInstance) targetable() {}
@@ -0,0 +1,301 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Targetable is implemented by all address types which can be targeted,
+// i.e. ModuleInstance, AbsResource and AbsResourceInstance
+type Targetable interface {
+	String() string
+	targetable()
+}
+
+// Target represents a parsed address, such as one passed
+// via -target or within moved, import and removed blocks
+type Target struct {
+	Subject     Targetable
+	SourceRange hcl.Range
+}
+
+// ParseTarget parses the given traversal as an address
+// of a module instance, resource or resource instance
+func ParseTarget(traversal hcl.Traversal) (*Target, hcl.Diagnostics) {
+	path, remain, diags := parseModuleInstancePrefix(traversal)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	rng := traversal.SourceRange()
+
+	if len(remain) == 0 {
+		return &Target{
+			Subject:     path,
+			SourceRange: rng,
+		}, diags
+	}
+
+	riAddr, riDiags := parseResourceInstanceUnderModule(path, remain)
+	diags = append(diags, riDiags...)
+	if riDiags.HasErrors() {
+		return nil, diags
+	}
+
+	var subject Targetable
+	if riAddr.Resource.Key == NoKey {
+		subject = riAddr.ContainingResource()
+	} else {
+		subject = riAddr
+	}
+
+	return &Target{
+		Subject:     subject,
+		SourceRange: rng,
+	}, diags
+}
+
+// ParseTargetStr is like ParseTarget, but parses the address from a string,
+// such as the value of the -target CLI option
+func ParseTargetStr(str string) (*Target, hcl.Diagnostics) {
+	traversal, diags := hclsyntax.ParseTraversalAbs([]byte(str), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return ParseTarget(traversal)
+}
+
+// ParseModuleInstance parses the given traversal
+// as an address of a module instance
+func ParseModuleInstance(traversal hcl.Traversal) (ModuleInstance, hcl.Diagnostics) {
+	path, remain, diags := parseModuleInstancePrefix(traversal)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	if len(remain) != 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid address",
+			Detail:   "A module instance address must contain only module calls.",
+			Subject:  remain.SourceRange().Ptr(),
+		})
+		return nil, diags
+	}
+
+	return path, diags
+}
+
+// ParseAbsResourceInstance parses the given traversal as an address
+// of a resource instance. Addresses without an instance key are
+// returned with NoKey.
+func ParseAbsResourceInstance(traversal hcl.Traversal) (AbsResourceInstance, hcl.Diagnostics) {
+	path, remain, diags := parseModuleInstancePrefix(traversal)
+	if diags.HasErrors() {
+		return AbsResourceInstance{}, diags
+	}
+
+	riAddr, riDiags := parseResourceInstanceUnderModule(path, remain)
+	diags = append(diags, riDiags...)
+	return riAddr, diags
+}
+
+func parseModuleInstancePrefix(traversal hcl.Traversal) (ModuleInstance, hcl.Traversal, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	path := make(ModuleInstance, 0)
+
+	remain := traversal
+	for len(remain) > 0 {
+		name, ok := traversalStepName(remain[0])
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid address",
+				Detail:   "Module address must start with \"module.\".",
+				Subject:  remain[0].SourceRange().Ptr(),
+			})
+			return nil, nil, diags
+		}
+		if name != "module" {
+			break
+		}
+
+		if len(remain) < 2 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid address",
+				Detail:   "Prefix \"module.\" must be followed by a module name.",
+				Subject:  remain[0].SourceRange().Ptr(),
+			})
+			return nil, nil, diags
+		}
+
+		callName, ok := remain[1].(hcl.TraverseAttr)
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid address",
+				Detail:   "Prefix \"module.\" must be followed by a module name.",
+				Subject:  remain[1].SourceRange().Ptr(),
+			})
+			return nil, nil, diags
+		}
+		remain = remain[2:]
+
+		step := ModuleInstanceStep{
+			Name: callName.Name,
+		}
+		if len(remain) > 0 {
+			if idx, ok := remain[0].(hcl.TraverseIndex); ok {
+				key, err := ParseInstanceKey(idx.Key)
+				if err != nil {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid address",
+						Detail:   fmt.Sprintf("Invalid module instance key: %s.", err),
+						Subject:  idx.SourceRange().Ptr(),
+					})
+					return nil, nil, diags
+				}
+				step.InstanceKey = key
+				remain = remain[1:]
+			}
+		}
+
+		path = append(path, step)
+	}
+
+	return path, remain, diags
+}
+
+func parseResourceInstanceUnderModule(path ModuleInstance, remain hcl.Traversal) (AbsResourceInstance, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	if len(remain) == 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid address",
+			Detail:   "Resource specification must include a resource type and name.",
+		})
+		return AbsResourceInstance{}, diags
+	}
+
+	mode := ManagedResourceMode
+	first, _ := traversalStepName(remain[0])
+	switch first {
+	case "data":
+		mode = DataResourceMode
+		remain = remain[1:]
+	case "ephemeral":
+		mode = EphemeralResourceMode
+		remain = remain[1:]
+	case "resource":
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid address",
+			Detail:   "A resource type name is required, and \"resource\" is not a valid resource type.",
+			Subject:  remain[0].SourceRange().Ptr(),
+		})
+		return AbsResourceInstance{}, diags
+	}
+
+	if len(remain) < 2 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid address",
+			Detail:   "Resource specification must include a resource type and name.",
+			Subject:  remain.SourceRange().Ptr(),
+		})
+		return AbsResourceInstance{}, diags
+	}
+
+	typeName, ok := traversalStepName(remain[0])
+	if !ok {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid address",
+			Detail:   "A resource type name is required.",
+			Subject:  remain[0].SourceRange().Ptr(),
+		})
+		return AbsResourceInstance{}, diags
+	}
+	nameStep, ok := remain[1].(hcl.TraverseAttr)
+	if !ok {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid address",
+			Detail:   "A resource name is required.",
+			Subject:  remain[1].SourceRange().Ptr(),
+		})
+		return AbsResourceInstance{}, diags
+	}
+	remain = remain[2:]
+
+	addr := AbsResourceInstance{
+		Module: path,
+		Resource: ResourceInstance{
+			Resource: Resource{
+				Mode: mode,
+				Type: typeName,
+				Name: nameStep.Name,
+			},
+		},
+	}
+
+	if len(remain) > 0 {
+		idx, ok := remain[0].(hcl.TraverseIndex)
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid address",
+				Detail:   "Resource instance key must be given in square brackets.",
+				Subject:  remain[0].SourceRange().Ptr(),
+			})
+			return AbsResourceInstance{}, diags
+		}
+
+		key, err := ParseInstanceKey(idx.Key)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid address",
+				Detail:   fmt.Sprintf("Invalid resource instance key: %s.", err),
+				Subject:  idx.SourceRange().Ptr(),
+			})
+			return AbsResourceInstance{}, diags
+		}
+		addr.Resource.Key = key
+		remain = remain[1:]
+	}
+
+	if len(remain) > 0 {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid address",
+			Detail:   "Unexpected extra operators after address.",
+			Subject:  remain.SourceRange().Ptr(),
+		})
+		return AbsResourceInstance{}, diags
+	}
+
+	return addr, diags
+}
+
+func traversalStepName(step hcl.Traverser) (string, bool) {
+	switch ts := step.(type) {
+	case hcl.TraverseRoot:
+		return ts.Name, true
+	case hcl.TraverseAttr:
+		return ts.Name, true
+	default:
+		return "", false
+	}
+}
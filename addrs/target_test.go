@@ -0,0 +1,145 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTargetStr(t *testing.T) {
+	testCases := []struct {
+		input           string
+		expectedSubject Targetable
+		expectedErr     bool
+	}{
+		{
+			"module.foo",
+			ModuleInstance{
+				{Name: "foo"},
+			},
+			false,
+		},
+		{
+			`module.net["a"].module.subnets[0]`,
+			ModuleInstance{
+				{Name: "net", InstanceKey: StringKey("a")},
+				{Name: "subnets", InstanceKey: IntKey(0)},
+			},
+			false,
+		},
+		{
+			"aws_instance.web",
+			AbsResource{
+				Module: ModuleInstance{},
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "aws_instance",
+					Name: "web",
+				},
+			},
+			false,
+		},
+		{
+			"data.aws_ami.ubuntu",
+			AbsResource{
+				Module: ModuleInstance{},
+				Resource: Resource{
+					Mode: DataResourceMode,
+					Type: "aws_ami",
+					Name: "ubuntu",
+				},
+			},
+			false,
+		},
+		{
+			`module.net["a"].aws_subnet.this[0]`,
+			AbsResourceInstance{
+				Module: ModuleInstance{
+					{Name: "net", InstanceKey: StringKey("a")},
+				},
+				Resource: ResourceInstance{
+					Resource: Resource{
+						Mode: ManagedResourceMode,
+						Type: "aws_subnet",
+						Name: "this",
+					},
+					Key: IntKey(0),
+				},
+			},
+			false,
+		},
+		{
+			`ephemeral.random_password.db["x"]`,
+			AbsResourceInstance{
+				Module: ModuleInstance{},
+				Resource: ResourceInstance{
+					Resource: Resource{
+						Mode: EphemeralResourceMode,
+						Type: "random_password",
+						Name: "db",
+					},
+					Key: StringKey("x"),
+				},
+			},
+			false,
+		},
+		{
+			"aws_instance",
+			nil,
+			true,
+		},
+		{
+			"module",
+			nil,
+			true,
+		},
+		{
+			"aws_instance.web[1.5]",
+			nil,
+			true,
+		},
+		{
+			"aws_instance.web.foo",
+			nil,
+			true,
+		},
+		{
+			"resource.aws_instance.web",
+			nil,
+			true,
+		},
+		{
+			"data.aws_ami",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			target, diags := ParseTargetStr(tc.input)
+			if tc.expectedErr {
+				if !diags.HasErrors() {
+					t.Fatalf("expected error for %q, %#v given", tc.input, target)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			if diff := cmp.Diff(tc.expectedSubject, target.Subject); diff != "" {
+				t.Fatalf("unexpected subject: %s", diff)
+			}
+
+			if target.Subject.String() != tc.input {
+				t.Fatalf("expected %q to round-trip, %q given", tc.input, target.Subject.String())
+			}
+		})
+	}
+}
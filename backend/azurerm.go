@@ -0,0 +1,31 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// AzureRM represents the configuration of the azurerm backend
+type AzureRM struct {
+	StorageAccountName string
+	ContainerName      string
+	Key                string
+	ResourceGroupName  string
+	Environment        string
+	SubscriptionID     string
+	TenantID           string
+}
+
+func (a *AzureRM) Copy() BackendData {
+	cpy := *a
+	return &cpy
+}
+
+func (a *AzureRM) Equals(d BackendData) bool {
+	data, ok := d.(*AzureRM)
+	if !ok {
+		return false
+	}
+
+	return *data == *a
+}
@@ -0,0 +1,30 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// Consul represents the configuration of the consul backend
+type Consul struct {
+	Address    string
+	Scheme     string
+	Path       string
+	Datacenter string
+	Gzip       bool
+	Lock       bool
+}
+
+func (c *Consul) Copy() BackendData {
+	cpy := *c
+	return &cpy
+}
+
+func (c *Consul) Equals(d BackendData) bool {
+	data, ok := d.(*Consul)
+	if !ok {
+		return false
+	}
+
+	return *data == *c
+}
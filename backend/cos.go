@@ -0,0 +1,30 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// COS represents the configuration of the cos (Tencent Cloud Object
+// Storage) backend
+type COS struct {
+	Region  string
+	Bucket  string
+	Prefix  string
+	Key     string
+	Encrypt bool
+}
+
+func (c *COS) Copy() BackendData {
+	cpy := *c
+	return &cpy
+}
+
+func (c *COS) Equals(d BackendData) bool {
+	data, ok := d.(*COS)
+	if !ok {
+		return false
+	}
+
+	return *data == *c
+}
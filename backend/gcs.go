@@ -0,0 +1,26 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// GCS represents the configuration of the gcs backend
+type GCS struct {
+	Bucket string
+	Prefix string
+}
+
+func (g *GCS) Copy() BackendData {
+	cpy := *g
+	return &cpy
+}
+
+func (g *GCS) Equals(d BackendData) bool {
+	data, ok := d.(*GCS)
+	if !ok {
+		return false
+	}
+
+	return *data == *g
+}
@@ -0,0 +1,30 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// HTTP represents the configuration of the http backend
+type HTTP struct {
+	Address       string
+	UpdateMethod  string
+	LockAddress   string
+	LockMethod    string
+	UnlockAddress string
+	UnlockMethod  string
+}
+
+func (h *HTTP) Copy() BackendData {
+	cpy := *h
+	return &cpy
+}
+
+func (h *HTTP) Equals(d BackendData) bool {
+	data, ok := d.(*HTTP)
+	if !ok {
+		return false
+	}
+
+	return *data == *h
+}
@@ -0,0 +1,42 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+import "maps"
+
+// Kubernetes represents the configuration of the kubernetes backend
+type Kubernetes struct {
+	SecretSuffix    string
+	Namespace       string
+	Labels          map[string]string
+	InClusterConfig bool
+	ConfigPath      string
+	ConfigContext   string
+	Host            string
+}
+
+func (k *Kubernetes) Copy() BackendData {
+	cpy := *k
+	if k.Labels != nil {
+		cpy.Labels = maps.Clone(k.Labels)
+	}
+	return &cpy
+}
+
+func (k *Kubernetes) Equals(d BackendData) bool {
+	data, ok := d.(*Kubernetes)
+	if !ok {
+		return false
+	}
+
+	return data.SecretSuffix == k.SecretSuffix &&
+		data.Namespace == k.Namespace &&
+		maps.Equal(data.Labels, k.Labels) &&
+		data.InClusterConfig == k.InClusterConfig &&
+		data.ConfigPath == k.ConfigPath &&
+		data.ConfigContext == k.ConfigContext &&
+		data.Host == k.Host
+}
@@ -0,0 +1,26 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// Local represents the configuration of the local backend
+type Local struct {
+	Path         string
+	WorkspaceDir string
+}
+
+func (l *Local) Copy() BackendData {
+	cpy := *l
+	return &cpy
+}
+
+func (l *Local) Equals(d BackendData) bool {
+	data, ok := d.(*Local)
+	if !ok {
+		return false
+	}
+
+	return *data == *l
+}
@@ -0,0 +1,33 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// OSS represents the configuration of the oss (Alibaba Cloud Object
+// Storage Service) backend
+type OSS struct {
+	Region             string
+	Endpoint           string
+	Bucket             string
+	Prefix             string
+	Key                string
+	TablestoreEndpoint string
+	TablestoreTable    string
+	Encrypt            bool
+}
+
+func (o *OSS) Copy() BackendData {
+	cpy := *o
+	return &cpy
+}
+
+func (o *OSS) Equals(d BackendData) bool {
+	data, ok := d.(*OSS)
+	if !ok {
+		return false
+	}
+
+	return *data == *o
+}
@@ -0,0 +1,26 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// Pg represents the configuration of the pg (PostgreSQL) backend
+type Pg struct {
+	ConnStr    string
+	SchemaName string
+}
+
+func (p *Pg) Copy() BackendData {
+	cpy := *p
+	return &cpy
+}
+
+func (p *Pg) Equals(d BackendData) bool {
+	data, ok := d.(*Pg)
+	if !ok {
+		return false
+	}
+
+	return *data == *p
+}
@@ -0,0 +1,33 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package backend
+
+// S3 represents the configuration of the s3 backend
+type S3 struct {
+	Bucket             string
+	Key                string
+	Region             string
+	WorkspaceKeyPrefix string
+	DynamoDBTable      string
+	UseLockfile        bool
+	Encrypt            bool
+	KMSKeyID           string
+	Profile            string
+}
+
+func (s *S3) Copy() BackendData {
+	cpy := *s
+	return &cpy
+}
+
+func (s *S3) Equals(d BackendData) bool {
+	data, ok := d.(*S3)
+	if !ok {
+		return false
+	}
+
+	return *data == *s
+}
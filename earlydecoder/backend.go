@@ -6,20 +6,22 @@
 package earlydecoder
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/opentofu/opentofu-schema/backend"
 	"github.com/zclconf/go-cty/cty"
 )
 
 func decodeBackendsBlock(block *hcl.Block) (backend.BackendData, hcl.Diagnostics) {
 	bType := block.Labels[0]
-	attrs, diags := block.Body.JustAttributes()
 
-	switch bType {
-	case "remote":
+	if bType == "remote" {
+		attrs, _ := block.Body.JustAttributes()
 		if attr, ok := attrs["hostname"]; ok {
-			val, vDiags := attr.Expr.Value(nil)
-			diags = append(diags, vDiags...)
+			val, _ := attr.Expr.Value(nil)
 			if val.IsWhollyKnown() && val.Type() == cty.String {
 				return &backend.Remote{
 					Hostname: val.AsString(),
@@ -30,7 +32,171 @@ func decodeBackendsBlock(block *hcl.Block) (backend.BackendData, hcl.Diagnostics
 		return &backend.Remote{}, nil
 	}
 
-	return &backend.UnknownBackendData{}, diags
+	data := newBackendData(bType)
+	targets := backendAttributeTargets(data)
+	if targets == nil {
+		_, diags := block.Body.JustAttributes()
+		return data, diags
+	}
+
+	return data, decodeBackendAttributes(block.Body, targets)
+}
+
+// newBackendData returns empty backend data for the given backend type
+func newBackendData(bType string) backend.BackendData {
+	switch bType {
+	case "remote":
+		return &backend.Remote{}
+	case "s3":
+		return &backend.S3{}
+	case "gcs":
+		return &backend.GCS{}
+	case "azurerm":
+		return &backend.AzureRM{}
+	case "pg":
+		return &backend.Pg{}
+	case "http":
+		return &backend.HTTP{}
+	case "kubernetes":
+		return &backend.Kubernetes{}
+	case "consul":
+		return &backend.Consul{}
+	case "cos":
+		return &backend.COS{}
+	case "oss":
+		return &backend.OSS{}
+	case "local":
+		return &backend.Local{}
+	}
+
+	return &backend.UnknownBackendData{}
+}
+
+// backendAttributeTargets maps attribute names of the backend configuration
+// to the fields of the given backend data they are decoded into.
+// It returns nil for backend data which is not decoded.
+func backendAttributeTargets(data backend.BackendData) map[string]any {
+	switch data := data.(type) {
+	case *backend.Remote:
+		return map[string]any{
+			"hostname": &data.Hostname,
+		}
+	case *backend.S3:
+		return map[string]any{
+			"bucket":               &data.Bucket,
+			"key":                  &data.Key,
+			"region":               &data.Region,
+			"workspace_key_prefix": &data.WorkspaceKeyPrefix,
+			"dynamodb_table":       &data.DynamoDBTable,
+			"use_lockfile":         &data.UseLockfile,
+			"encrypt":              &data.Encrypt,
+			"kms_key_id":           &data.KMSKeyID,
+			"profile":              &data.Profile,
+		}
+	case *backend.GCS:
+		return map[string]any{
+			"bucket": &data.Bucket,
+			"prefix": &data.Prefix,
+		}
+	case *backend.AzureRM:
+		return map[string]any{
+			"storage_account_name": &data.StorageAccountName,
+			"container_name":       &data.ContainerName,
+			"key":                  &data.Key,
+			"resource_group_name":  &data.ResourceGroupName,
+			"environment":          &data.Environment,
+			"subscription_id":      &data.SubscriptionID,
+			"tenant_id":            &data.TenantID,
+		}
+	case *backend.Pg:
+		return map[string]any{
+			"conn_str":    &data.ConnStr,
+			"schema_name": &data.SchemaName,
+		}
+	case *backend.HTTP:
+		return map[string]any{
+			"address":        &data.Address,
+			"update_method":  &data.UpdateMethod,
+			"lock_address":   &data.LockAddress,
+			"lock_method":    &data.LockMethod,
+			"unlock_address": &data.UnlockAddress,
+			"unlock_method":  &data.UnlockMethod,
+		}
+	case *backend.Kubernetes:
+		return map[string]any{
+			"secret_suffix":     &data.SecretSuffix,
+			"namespace":         &data.Namespace,
+			"labels":            &data.Labels,
+			"in_cluster_config": &data.InClusterConfig,
+			"config_path":       &data.ConfigPath,
+			"config_context":    &data.ConfigContext,
+			"host":              &data.Host,
+		}
+	case *backend.Consul:
+		return map[string]any{
+			"address":    &data.Address,
+			"scheme":     &data.Scheme,
+			"path":       &data.Path,
+			"datacenter": &data.Datacenter,
+			"gzip":       &data.Gzip,
+			"lock":       &data.Lock,
+		}
+	case *backend.COS:
+		return map[string]any{
+			"region":  &data.Region,
+			"bucket":  &data.Bucket,
+			"prefix":  &data.Prefix,
+			"key":     &data.Key,
+			"encrypt": &data.Encrypt,
+		}
+	case *backend.OSS:
+		return map[string]any{
+			"region":              &data.Region,
+			"endpoint":            &data.Endpoint,
+			"bucket":              &data.Bucket,
+			"prefix":              &data.Prefix,
+			"key":                 &data.Key,
+			"tablestore_endpoint": &data.TablestoreEndpoint,
+			"tablestore_table":    &data.TablestoreTable,
+			"encrypt":             &data.Encrypt,
+		}
+	case *backend.Local:
+		return map[string]any{
+			"path":          &data.Path,
+			"workspace_dir": &data.WorkspaceDir,
+		}
+	}
+
+	return nil
+}
+
+// decodeBackendAttributes decodes the given attributes of a backend body
+// into the given targets (pointers to fields of the backend data), keyed by
+// attribute name. Any other attributes or nested blocks are ignored.
+func decodeBackendAttributes(body hcl.Body, targets map[string]any) hcl.Diagnostics {
+	bodySchema := &hcl.BodySchema{
+		Attributes: make([]hcl.AttributeSchema, 0, len(targets)),
+	}
+	for name := range targets {
+		bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{
+			Name: name,
+		})
+	}
+
+	content, _, diags := body.PartialContent(bodySchema)
+	// Decode in a stable order to keep diagnostics deterministic
+	for _, name := range slices.Sorted(maps.Keys(content.Attributes)) {
+		expr := content.Attributes[name].Expr
+		// Variables and locals are allowed in backend configuration
+		// since OpenTofu 1.8, but their values aren't known here
+		if len(expr.Variables()) > 0 {
+			continue
+		}
+		valDiags := gohcl.DecodeExpression(expr, nil, targets[name])
+		diags = append(diags, valDiags...)
+	}
+
+	return diags
 }
 
 func decodeCloudBlock(block *hcl.Block) (*backend.Cloud, hcl.Diagnostics) {
@@ -0,0 +1,48 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+// MergeBackendConfigFile merges a partial backend configuration file
+// (*.tfbackend), as passed to `tofu init -backend-config=...`, onto the
+// given backend declared in the module.
+//
+// Attributes set in the file take precedence over the ones declared in the
+// backend block. The given backend is left untouched and a merged copy is
+// returned instead.
+func MergeBackendConfigFile(be *module.Backend, file *hcl.File) (*module.Backend, hcl.Diagnostics) {
+	if be == nil {
+		return nil, hcl.Diagnostics{
+			&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing backend configuration",
+				Detail:   "A partial backend configuration file can only be used with a module which declares a backend block.",
+				Subject:  file.Body.MissingItemRange().Ptr(),
+			},
+		}
+	}
+
+	merged := &module.Backend{
+		Type: be.Type,
+	}
+	if be.Data != nil {
+		merged.Data = be.Data.Copy()
+	} else {
+		merged.Data = newBackendData(be.Type)
+	}
+
+	targets := backendAttributeTargets(merged.Data)
+	if targets == nil {
+		_, diags := file.Body.JustAttributes()
+		return merged, diags
+	}
+
+	return merged, decodeBackendAttributes(file.Body, targets)
+}
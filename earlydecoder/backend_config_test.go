@@ -0,0 +1,138 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/backend"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+func TestMergeBackendConfigFile(t *testing.T) {
+	testCases := []struct {
+		name            string
+		backend         *module.Backend
+		cfg             string
+		expectedBackend *module.Backend
+		expectedDiags   int
+	}{
+		{
+			"s3 partial configuration",
+			&module.Backend{
+				Type: "s3",
+				Data: &backend.S3{
+					Key:    "network/terraform.tfstate",
+					Region: "eu-west-1",
+				},
+			},
+			`
+bucket = "prod-state"
+region = "us-east-1"
+`,
+			&module.Backend{
+				Type: "s3",
+				Data: &backend.S3{
+					Bucket: "prod-state",
+					Key:    "network/terraform.tfstate",
+					Region: "us-east-1",
+				},
+			},
+			0,
+		},
+		{
+			"empty backend block",
+			&module.Backend{
+				Type: "gcs",
+				Data: &backend.GCS{},
+			},
+			`
+bucket = "state"
+prefix = "prod"
+`,
+			&module.Backend{
+				Type: "gcs",
+				Data: &backend.GCS{
+					Bucket: "state",
+					Prefix: "prod",
+				},
+			},
+			0,
+		},
+		{
+			"untyped backend",
+			&module.Backend{
+				Type: "etcdv3",
+				Data: &backend.UnknownBackendData{},
+			},
+			`
+endpoints = ["etcd-1:2379"]
+`,
+			&module.Backend{
+				Type: "etcdv3",
+				Data: &backend.UnknownBackendData{},
+			},
+			0,
+		},
+		{
+			"invalid value type",
+			&module.Backend{
+				Type: "local",
+				Data: &backend.Local{},
+			},
+			`
+path = ["foo"]
+`,
+			&module.Backend{
+				Type: "local",
+				Data: &backend.Local{},
+			},
+			1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(tc.cfg), "prod.tfbackend", hcl.InitialPos)
+			if len(diags) > 0 {
+				t.Fatal(diags)
+			}
+
+			original := &module.Backend{
+				Type: tc.backend.Type,
+				Data: tc.backend.Data.Copy(),
+			}
+
+			merged, diags := MergeBackendConfigFile(tc.backend, f)
+			if len(diags) != tc.expectedDiags {
+				t.Fatalf("expected %d diagnostics, got %d: %s", tc.expectedDiags, len(diags), diags)
+			}
+
+			if diff := cmp.Diff(tc.expectedBackend, merged); diff != "" {
+				t.Fatalf("backend mismatch: %s", diff)
+			}
+
+			if !original.Equals(tc.backend) {
+				t.Fatalf("expected original backend to remain unchanged")
+			}
+		})
+	}
+}
+
+func TestMergeBackendConfigFile_noBackend(t *testing.T) {
+	f, diags := hclsyntax.ParseConfig([]byte(`bucket = "state"`), "prod.tfbackend", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	_, diags = MergeBackendConfigFile(nil, f)
+	if !diags.HasErrors() {
+		t.Fatal("expected error for missing backend")
+	}
+}
@@ -8,13 +8,18 @@ package earlydecoder
 import (
 	"fmt"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu-schema/module"
 )
 
 type dataSource struct {
-	Type     string
-	Name     string
-	Provider module.ProviderRef
+	Type       string
+	Name       string
+	Provider   module.ProviderRef
+	HasCount   bool
+	HasForEach bool
+	DefRange   hcl.Range
+	NameRange  hcl.Range
 }
 
 // MapKey returns a string that can be used to uniquely identify the receiver
@@ -22,3 +27,24 @@ type dataSource struct {
 func (r *dataSource) MapKey() string {
 	return fmt.Sprintf("data.%s.%s", r.Type, r.Name)
 }
+
+func (r *dataSource) overrideTarget() *resourceOverrideTarget {
+	return &resourceOverrideTarget{
+		Provider:   &r.Provider,
+		HasCount:   &r.HasCount,
+		HasForEach: &r.HasForEach,
+	}
+}
+
+// toModule converts the receiver into the exported representation
+func (r *dataSource) toModule() module.Resource {
+	return module.Resource{
+		Type:         r.Type,
+		Name:         r.Name,
+		Provider:     r.Provider,
+		HasCount:     r.HasCount,
+		HasForEach:   r.HasForEach,
+		DefRangePtr:  r.DefRange.Ptr(),
+		NameRangePtr: r.NameRange.Ptr(),
+	}
+}
@@ -13,21 +13,41 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu-schema/internal/addr"
 	"github.com/opentofu/opentofu-schema/module"
+	"github.com/opentofu/opentofu-schema/staticeval"
 	tfaddr "github.com/opentofu/registry-address"
 )
 
 func LoadModule(path string, files map[string]*hcl.File) (*module.Meta, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
-	filenames := make([]string, 0)
+	allFilenames := make([]string, 0, len(files))
+	for filename := range files {
+		allFilenames = append(allFilenames, filename)
+	}
+
+	// Files shadowed by their .tofu counterpart are ignored by OpenTofu
+	filenames, shadowedFilenames := selectModuleFiles(allFilenames)
+
+	// Override files are merged into the primary ones, so we need to
+	// load them last, in lexical order
+	primaryFilenames := make([]string, 0, len(filenames))
+	overrideFilenames := make([]string, 0)
+	for _, filename := range filenames {
+		if module.IsOverrideFile(filename) {
+			overrideFilenames = append(overrideFilenames, filename)
+			continue
+		}
+		primaryFilenames = append(primaryFilenames, filename)
+	}
 
 	mod := newDecodedModule()
-	for filename, f := range files {
-		filenames = append(filenames, filename)
-		fDiags := loadModuleFromFile(f, mod)
+	for _, filename := range primaryFilenames {
+		fDiags := loadModuleFromFile(files[filename], mod, false)
+		diags = append(diags, fDiags...)
+	}
+	for _, filename := range overrideFilenames {
+		fDiags := loadModuleFromFile(files[filename], mod, true)
 		diags = append(diags, fDiags...)
 	}
-
-	sort.Strings(filenames)
 
 	var coreRequirements version.Constraints
 	for _, rc := range mod.RequiredCore {
@@ -47,8 +67,10 @@ func LoadModule(path string, files map[string]*hcl.File) (*module.Meta, hcl.Diag
 	if len(mod.Backends) == 1 {
 		for bType, data := range mod.Backends {
 			backend = &module.Backend{
-				Type: bType,
-				Data: data,
+				Type:         bType,
+				Data:         data,
+				DefRangePtr:  mod.backendRanges[bType].DefRange.Ptr(),
+				NameRangePtr: mod.backendRanges[bType].NameRange.Ptr(),
 			}
 		}
 	} else if len(mod.Backends) > 1 {
@@ -129,6 +151,7 @@ func LoadModule(path string, files map[string]*hcl.File) (*module.Meta, hcl.Diag
 		}
 	}
 
+	providerConfigs := make(map[module.ProviderRef]module.ProviderConfig)
 	for _, cfg := range mod.ProviderConfigs {
 		src := refs[module.ProviderRef{
 			LocalName: cfg.Name,
@@ -139,8 +162,20 @@ func LoadModule(path string, files map[string]*hcl.File) (*module.Meta, hcl.Diag
 				Alias:     cfg.Alias,
 			}] = src
 		}
+
+		providerConfigs[module.ProviderRef{
+			LocalName: cfg.Name,
+			Alias:     cfg.Alias,
+		}] = module.ProviderConfig{
+			LocalName:    cfg.Name,
+			Alias:        cfg.Alias,
+			DefRangePtr:  cfg.DefRange.Ptr(),
+			NameRangePtr: cfg.NameRange.Ptr(),
+		}
 	}
 
+	declaredRequirements := declaredProviderRequirements(mod.ProviderRequirements, refs)
+
 	for _, resource := range mod.Resources {
 		constraintDiags := addProviderReferences(resource.Provider.LocalName, providerRequirements, refs)
 		diags = append(diags, constraintDiags...)
@@ -156,6 +191,9 @@ func LoadModule(path string, files map[string]*hcl.File) (*module.Meta, hcl.Diag
 		diags = append(diags, constraintDiags...)
 	}
 
+	evalCtx := staticEvalContext(mod, staticeval.Functions(coreRequirements))
+	resolveOutputs(mod, evalCtx)
+
 	variables := make(map[string]module.Variable)
 	for key, variable := range mod.Variables {
 		variables[key] = *variable
@@ -166,9 +204,26 @@ func LoadModule(path string, files map[string]*hcl.File) (*module.Meta, hcl.Diag
 		outputs[key] = *output
 	}
 
+	resources := make(map[string]module.Resource)
+	for key, resource := range mod.Resources {
+		resources[key] = resource.toModule()
+	}
+
+	dataSources := make(map[string]module.Resource)
+	for key, dataSource := range mod.DataSources {
+		dataSources[key] = dataSource.toModule()
+	}
+
+	ephemeralResources := make(map[string]module.Resource)
+	for key, ephemeralResource := range mod.EphemeralResources {
+		ephemeralResources[key] = ephemeralResource.toModule()
+	}
+
+	diags = append(diags, validateMovedChains(mod.Moved)...)
+
 	// Resolve module calls whose source or version reference variables or
 	// locals that are known
-	resolveStaticModuleCalls(mod)
+	resolveStaticModuleCalls(mod, evalCtx)
 
 	modulesCalls := make(map[string]module.DeclaredModuleCall)
 	for key, moduleCall := range mod.ModuleCalls {
@@ -185,10 +240,46 @@ func LoadModule(path string, files map[string]*hcl.File) (*module.Meta, hcl.Diag
 		Variables:            variables,
 		Outputs:              outputs,
 		Filenames:            filenames,
+		ShadowedFilenames:    shadowedFilenames,
 		ModuleCalls:          modulesCalls,
+		Resources:            resources,
+		DataSources:          dataSources,
+		EphemeralResources:   ephemeralResources,
+		ProviderConfigs:      providerConfigs,
+
+		DeclaredProviderRequirements: declaredRequirements,
+		Moved:                        mod.Moved,
+		Imports:                      mod.Imports,
+		Removed:                      mod.Removed,
 	}, diags
 }
 
+// declaredProviderRequirements lists all declared required_providers
+// entries, ordered by their position in the configuration
+func declaredProviderRequirements(reqs map[string]*providerRequirement, refs map[module.ProviderRef]tfaddr.Provider) []module.DeclaredProviderRequirement {
+	var declared []module.DeclaredProviderRequirement
+	for name, req := range reqs {
+		for _, decl := range req.Declarations {
+			declared = append(declared, module.DeclaredProviderRequirement{
+				LocalName:    name,
+				Source:       refs[module.ProviderRef{LocalName: name}],
+				DefRangePtr:  decl.DefRange.Ptr(),
+				NameRangePtr: decl.NameRange.Ptr(),
+			})
+		}
+	}
+
+	sort.SliceStable(declared, func(i, j int) bool {
+		iRng, jRng := declared[i].DefRangePtr, declared[j].DefRangePtr
+		if iRng.Filename != jRng.Filename {
+			return iRng.Filename < jRng.Filename
+		}
+		return iRng.Start.Byte < jRng.Start.Byte
+	})
+
+	return declared
+}
+
 // addProviderReferences given a provider with a local name (extracted from resources and data sources)
 // if not already present - adds it to the passed requirements and references
 func addProviderReferences(localProviderName string, reqs map[tfaddr.Provider]version.Constraints, refs map[module.ProviderRef]tfaddr.Provider) hcl.Diagnostics {
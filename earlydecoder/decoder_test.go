@@ -7,6 +7,7 @@ package earlydecoder
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
 	"github.com/opentofu/opentofu-schema/backend"
 	"github.com/opentofu/opentofu-schema/internal/addr"
 	"github.com/opentofu/opentofu-schema/module"
@@ -43,6 +45,10 @@ func TestLoadModule(t *testing.T) {
 			``,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -60,6 +66,10 @@ terraform {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				CoreRequirements:     version.MustConstraints(version.NewConstraint("~> 0.12")),
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -80,6 +90,10 @@ language {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				CoreRequirements:     version.MustConstraints(version.NewConstraint(">= 1.12")),
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -104,6 +118,10 @@ language {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				CoreRequirements:     version.MustConstraints(version.NewConstraint("~> 1.12, >= 1.12")),
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -136,6 +154,69 @@ provider "grafana" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws"}: {
+						LocalName: "aws",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 15, Byte: 15},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 15, Byte: 15},
+						},
+					},
+					{LocalName: "grafana"}: {
+						LocalName: "grafana",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 14, Column: 1, Byte: 166},
+							End:      hcl.Pos{Line: 14, Column: 19, Byte: 184},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 14, Column: 10, Byte: 175},
+							End:      hcl.Pos{Line: 14, Column: 19, Byte: 184},
+						},
+					},
+				},
+				Resources: map[string]module.Resource{
+					"google_storage_bucket.bucket": {
+						Type:     "google_storage_bucket",
+						Name:     "bucket",
+						Provider: module.ProviderRef{LocalName: "google"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 1, Byte: 44},
+							End:      hcl.Pos{Line: 6, Column: 42, Byte: 85},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 34, Byte: 77},
+							End:      hcl.Pos{Line: 6, Column: 42, Byte: 85},
+						},
+					},
+				},
+				DataSources: map[string]module.Resource{
+					"data.blah_foobar.test": {
+						Type:     "blah_foobar",
+						Name:     "test",
+						Provider: module.ProviderRef{LocalName: "blah"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 10, Column: 1, Byte: 114},
+							End:      hcl.Pos{Line: 10, Column: 26, Byte: 139},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 10, Column: 20, Byte: 133},
+							End:      hcl.Pos{Line: 10, Column: 26, Byte: 139},
+						},
+					},
+				},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}:     addr.NewLegacyProvider("aws"),
 					{LocalName: "blah"}:    addr.NewLegacyProvider("blah"),
@@ -179,6 +260,83 @@ provider "grafana" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws"}: {
+						LocalName: "aws",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 1, Byte: 84},
+							End:      hcl.Pos{Line: 8, Column: 15, Byte: 98},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 10, Byte: 93},
+							End:      hcl.Pos{Line: 8, Column: 15, Byte: 98},
+						},
+					},
+					{LocalName: "grafana"}: {
+						LocalName: "grafana",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 16, Column: 1, Byte: 197},
+							End:      hcl.Pos{Line: 16, Column: 19, Byte: 215},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 16, Column: 10, Byte: 206},
+							End:      hcl.Pos{Line: 16, Column: 19, Byte: 215},
+						},
+					},
+				},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "aws",
+						Source:    addr.NewLegacyProvider("aws"),
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 18, Byte: 53},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+					{
+						LocalName: "google",
+						Source:    addr.NewLegacyProvider("google"),
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 5, Byte: 58},
+							End:      hcl.Pos{Line: 5, Column: 24, Byte: 77},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 5, Column: 5, Byte: 58},
+							End:      hcl.Pos{Line: 5, Column: 11, Byte: 64},
+						},
+					},
+				},
+				Resources: map[string]module.Resource{
+					"google_storage_bucket.bucket": {
+						Type:     "google_storage_bucket",
+						Name:     "bucket",
+						Provider: module.ProviderRef{LocalName: "google"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 12, Column: 1, Byte: 127},
+							End:      hcl.Pos{Line: 12, Column: 42, Byte: 168},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 12, Column: 34, Byte: 160},
+							End:      hcl.Pos{Line: 12, Column: 42, Byte: 168},
+						},
+					},
+				},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}:     addr.NewLegacyProvider("aws"),
 					{LocalName: "google"}:  addr.NewLegacyProvider("google"),
@@ -224,6 +382,83 @@ provider "grafana" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws"}: {
+						LocalName: "aws",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 12, Column: 1, Byte: 130},
+							End:      hcl.Pos{Line: 12, Column: 15, Byte: 144},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 12, Column: 10, Byte: 139},
+							End:      hcl.Pos{Line: 12, Column: 15, Byte: 144},
+						},
+					},
+					{LocalName: "grafana"}: {
+						LocalName: "grafana",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 20, Column: 1, Byte: 243},
+							End:      hcl.Pos{Line: 20, Column: 19, Byte: 261},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 20, Column: 10, Byte: 252},
+							End:      hcl.Pos{Line: 20, Column: 19, Byte: 261},
+						},
+					},
+				},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "aws",
+						Source:    addr.NewLegacyProvider("aws"),
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 6, Column: 6, Byte: 76},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+					{
+						LocalName: "google",
+						Source:    addr.NewLegacyProvider("google"),
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 7, Column: 5, Byte: 81},
+							End:      hcl.Pos{Line: 9, Column: 6, Byte: 123},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 7, Column: 5, Byte: 81},
+							End:      hcl.Pos{Line: 7, Column: 11, Byte: 87},
+						},
+					},
+				},
+				Resources: map[string]module.Resource{
+					"google_storage_bucket.bucket": {
+						Type:     "google_storage_bucket",
+						Name:     "bucket",
+						Provider: module.ProviderRef{LocalName: "google"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 16, Column: 1, Byte: 173},
+							End:      hcl.Pos{Line: 16, Column: 42, Byte: 214},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 16, Column: 34, Byte: 206},
+							End:      hcl.Pos{Line: 16, Column: 42, Byte: 214},
+						},
+					},
+				},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}:     addr.NewLegacyProvider("aws"),
 					{LocalName: "google"}:  addr.NewLegacyProvider("google"),
@@ -275,6 +510,109 @@ provider "grafana" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws"}: {
+						LocalName: "aws",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 18, Column: 1, Byte: 276},
+							End:      hcl.Pos{Line: 18, Column: 15, Byte: 290},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 18, Column: 10, Byte: 285},
+							End:      hcl.Pos{Line: 18, Column: 15, Byte: 290},
+						},
+					},
+					{LocalName: "grafana"}: {
+						LocalName: "grafana",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 26, Column: 1, Byte: 389},
+							End:      hcl.Pos{Line: 26, Column: 19, Byte: 407},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 26, Column: 10, Byte: 398},
+							End:      hcl.Pos{Line: 26, Column: 19, Byte: 407},
+						},
+					},
+				},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "aws",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "aws",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 7, Column: 6, Byte: 109},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+					{
+						LocalName: "google",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "google",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 114},
+							End:      hcl.Pos{Line: 11, Column: 6, Byte: 189},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 114},
+							End:      hcl.Pos{Line: 8, Column: 11, Byte: 120},
+						},
+					},
+					{
+						LocalName: "grafana",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "grafana",
+							Type:      "grafana",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 12, Column: 5, Byte: 194},
+							End:      hcl.Pos{Line: 15, Column: 6, Byte: 269},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 12, Column: 5, Byte: 194},
+							End:      hcl.Pos{Line: 12, Column: 12, Byte: 201},
+						},
+					},
+				},
+				Resources: map[string]module.Resource{
+					"google_storage_bucket.bucket": {
+						Type:     "google_storage_bucket",
+						Name:     "bucket",
+						Provider: module.ProviderRef{LocalName: "google"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 22, Column: 1, Byte: 319},
+							End:      hcl.Pos{Line: 22, Column: 42, Byte: 360},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 22, Column: 34, Byte: 352},
+							End:      hcl.Pos{Line: 22, Column: 42, Byte: 360},
+						},
+					},
+				},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}: {
 						Hostname:  tfaddr.DefaultProviderRegistryHost,
@@ -350,6 +688,96 @@ resource "google_storage_bucket" "bucket" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws"}: {
+						LocalName: "aws",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 23, Column: 1, Byte: 284},
+							End:      hcl.Pos{Line: 23, Column: 15, Byte: 298},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 23, Column: 10, Byte: 293},
+							End:      hcl.Pos{Line: 23, Column: 15, Byte: 298},
+						},
+					},
+				},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "aws",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "aws",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 7, Column: 6, Byte: 112},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+					{
+						LocalName: "google",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "google",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 117},
+							End:      hcl.Pos{Line: 11, Column: 6, Byte: 192},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 117},
+							End:      hcl.Pos{Line: 8, Column: 11, Byte: 123},
+						},
+					},
+					{
+						LocalName: "aws",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "aws",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 17, Column: 5, Byte: 239},
+							End:      hcl.Pos{Line: 19, Column: 6, Byte: 276},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 17, Column: 5, Byte: 239},
+							End:      hcl.Pos{Line: 17, Column: 8, Byte: 242},
+						},
+					},
+				},
+				Resources: map[string]module.Resource{
+					"google_storage_bucket.bucket": {
+						Type:     "google_storage_bucket",
+						Name:     "bucket",
+						Provider: module.ProviderRef{LocalName: "google"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 27, Column: 1, Byte: 327},
+							End:      hcl.Pos{Line: 27, Column: 42, Byte: 368},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 27, Column: 34, Byte: 360},
+							End:      hcl.Pos{Line: 27, Column: 42, Byte: 368},
+						},
+					},
+				},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}: {
 						Hostname:  tfaddr.DefaultProviderRegistryHost,
@@ -416,6 +844,96 @@ resource "google_storage_bucket" "bucket" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws"}: {
+						LocalName: "aws",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 24, Column: 1, Byte: 314},
+							End:      hcl.Pos{Line: 24, Column: 15, Byte: 328},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 24, Column: 10, Byte: 323},
+							End:      hcl.Pos{Line: 24, Column: 15, Byte: 328},
+						},
+					},
+				},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "aws",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "aws",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 7, Column: 6, Byte: 112},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+					{
+						LocalName: "google",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "google",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 117},
+							End:      hcl.Pos{Line: 11, Column: 6, Byte: 192},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 117},
+							End:      hcl.Pos{Line: 8, Column: 11, Byte: 123},
+						},
+					},
+					{
+						LocalName: "aws",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "aws",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 17, Column: 5, Byte: 239},
+							End:      hcl.Pos{Line: 20, Column: 6, Byte: 306},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 17, Column: 5, Byte: 239},
+							End:      hcl.Pos{Line: 17, Column: 8, Byte: 242},
+						},
+					},
+				},
+				Resources: map[string]module.Resource{
+					"google_storage_bucket.bucket": {
+						Type:     "google_storage_bucket",
+						Name:     "bucket",
+						Provider: module.ProviderRef{LocalName: "google"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 28, Column: 1, Byte: 357},
+							End:      hcl.Pos{Line: 28, Column: 42, Byte: 398},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 28, Column: 34, Byte: 390},
+							End:      hcl.Pos{Line: 28, Column: 42, Byte: 398},
+						},
+					},
+				},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}: {
 						Hostname:  tfaddr.DefaultProviderRegistryHost,
@@ -469,6 +987,63 @@ provider "aws" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws", Alias: "euwest"}: {
+						LocalName: "aws",
+						Alias:     "euwest",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 14, Column: 1, Byte: 196},
+							End:      hcl.Pos{Line: 14, Column: 15, Byte: 210},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 14, Column: 10, Byte: 205},
+							End:      hcl.Pos{Line: 14, Column: 15, Byte: 210},
+						},
+					},
+				},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "aws",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "aws",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 7, Column: 6, Byte: 109},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+					{
+						LocalName: "google",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "google",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 114},
+							End:      hcl.Pos{Line: 11, Column: 6, Byte: 189},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 5, Byte: 114},
+							End:      hcl.Pos{Line: 8, Column: 11, Byte: 120},
+						},
+					},
+				},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}: {
 						Hostname:  tfaddr.DefaultProviderRegistryHost,
@@ -528,6 +1103,63 @@ provider "aws" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "aws", Alias: "west"}: {
+						LocalName: "aws",
+						Alias:     "west",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 15, Column: 1, Byte: 237},
+							End:      hcl.Pos{Line: 15, Column: 15, Byte: 251},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 15, Column: 10, Byte: 246},
+							End:      hcl.Pos{Line: 15, Column: 15, Byte: 251},
+						},
+					},
+				},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "aws",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "aws",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 8, Column: 6, Byte: 150},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+					{
+						LocalName: "google",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "google",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 9, Column: 5, Byte: 155},
+							End:      hcl.Pos{Line: 12, Column: 6, Byte: 230},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 9, Column: 5, Byte: 155},
+							End:      hcl.Pos{Line: 9, Column: 11, Byte: 161},
+						},
+					},
+				},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "aws"}: {
 						Hostname:  tfaddr.DefaultProviderRegistryHost,
@@ -586,12 +1218,52 @@ resource "google_something" "test" {
 }
 `,
 			&module.Meta{
-				Path: path,
-				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
-					{LocalName: "goo"}: {
-						Hostname:  tfaddr.DefaultProviderRegistryHost,
-						Namespace: "hashicorp",
-						Type:      "google-beta",
+				Path:            path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{},
+				DeclaredProviderRequirements: []module.DeclaredProviderRequirement{
+					{
+						LocalName: "goo",
+						Source: tfaddr.Provider{
+							Hostname:  tfaddr.DefaultProviderRegistryHost,
+							Namespace: "hashicorp",
+							Type:      "google-beta",
+						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 7, Column: 6, Byte: 117},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+							End:      hcl.Pos{Line: 4, Column: 8, Byte: 43},
+						},
+					},
+				},
+				Resources: map[string]module.Resource{
+					"google_something.test": {
+						Type:     "google_something",
+						Name:     "test",
+						Provider: module.ProviderRef{LocalName: "goo"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 11, Column: 1, Byte: 125},
+							End:      hcl.Pos{Line: 11, Column: 35, Byte: 159},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 11, Column: 29, Byte: 153},
+							End:      hcl.Pos{Line: 11, Column: 35, Byte: 159},
+						},
+					},
+				},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
+				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
+					{LocalName: "goo"}: {
+						Hostname:  tfaddr.DefaultProviderRegistryHost,
+						Namespace: "hashicorp",
+						Type:      "google-beta",
 					},
 				},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{
@@ -776,6 +1448,10 @@ variable "" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -792,6 +1468,10 @@ variable {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -840,6 +1520,10 @@ variable "one" "two" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -888,11 +1572,26 @@ variable "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
 					"name": {
 						Type: cty.DynamicPseudoType,
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -909,11 +1608,26 @@ variable "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
 					"name": {
 						Type: cty.String,
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -930,12 +1644,27 @@ variable "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
 					"name": {
 						Type:        cty.DynamicPseudoType,
 						Description: "description",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -952,12 +1681,27 @@ variable "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
 					"name": {
 						Type:        cty.DynamicPseudoType,
 						IsSensitive: true,
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -976,6 +1720,10 @@ variable "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
@@ -983,6 +1731,17 @@ variable "name" {
 						Type:        cty.String,
 						Description: "description",
 						IsSensitive: true,
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -999,12 +1758,27 @@ variable "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
 					"name": {
 						Type:         cty.DynamicPseudoType,
 						DefaultValue: cty.EmptyObjectVal,
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -1024,6 +1798,10 @@ variable "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
@@ -1041,6 +1819,17 @@ variable "name" {
 								"foo": cty.StringVal("food"),
 							},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 16, Byte: 16},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -1059,6 +1848,10 @@ variable "old_var" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables: map[string]module.Variable{
@@ -1066,6 +1859,17 @@ variable "old_var" {
 						Type:        cty.String,
 						Description: "This variable is deprecated",
 						Deprecated:  "Use new_var instead",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 19, Byte: 19},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 19, Byte: 19},
+						},
+						IsNullable: true,
 					},
 				},
 				Outputs:     map[string]module.Output{},
@@ -1084,14 +1888,29 @@ output "old_output" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
 				Outputs: map[string]module.Output{
 					"old_output": {
 						Value:       cty.StringVal("some_value"),
+						Type:        cty.String,
 						Description: "This output is deprecated",
 						Deprecated:  "Use new_output instead",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 20, Byte: 20},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 20, Byte: 20},
+						},
 					},
 				},
 				Filenames:   []string{"test.tf"},
@@ -1107,11 +1926,28 @@ output "name" {
 `,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
 				Outputs: map[string]module.Output{
-					"name": {Value: cty.NilVal},
+					"name": {
+						Value: cty.NilVal,
+						Type:  cty.DynamicPseudoType,
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+					},
 				},
 				Filenames:   []string{"test.tf"},
 				ModuleCalls: map[string]module.DeclaredModuleCall{},
@@ -1135,6 +1971,10 @@ terraform {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				Backend:              nil,
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -1150,14 +1990,166 @@ terraform {
 			`
 terraform {
   backend "s3" {
-  	blah = "test"
+    bucket         = "state"
+    key            = "network/terraform.tfstate"
+    region         = "eu-west-1"
+    dynamodb_table = "locks"
+    use_lockfile   = true
+    blah           = "test"
+
+    assume_role {
+      role_arn = "arn:aws:iam::123456789012:role/state"
+    }
   }
 }`,
 			&module.Meta{
-				Path: path,
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				Backend: &module.Backend{
 					Type: "s3",
+					Data: &backend.S3{
+						Bucket:        "state",
+						Key:           "network/terraform.tfstate",
+						Region:        "eu-west-1",
+						DynamoDBTable: "locks",
+						UseLockfile:   true,
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+						End:      hcl.Pos{Line: 3, Column: 15, Byte: 27},
+					},
+					NameRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						End:      hcl.Pos{Line: 3, Column: 15, Byte: 27},
+					},
+				},
+				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
+				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
+				Variables:            map[string]module.Variable{},
+				Outputs:              map[string]module.Output{},
+				Filenames:            []string{"test.tf"},
+				ModuleCalls:          map[string]module.DeclaredModuleCall{},
+			},
+			nil,
+		},
+		{
+			"kubernetes backend",
+			`
+terraform {
+  backend "kubernetes" {
+    secret_suffix = "state"
+    namespace     = "infra"
+    labels = {
+      team = "platform"
+    }
+    in_cluster_config = true
+  }
+}`,
+			&module.Meta{
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
+				Backend: &module.Backend{
+					Type: "kubernetes",
+					Data: &backend.Kubernetes{
+						SecretSuffix:    "state",
+						Namespace:       "infra",
+						Labels:          map[string]string{"team": "platform"},
+						InClusterConfig: true,
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+						End:      hcl.Pos{Line: 3, Column: 23, Byte: 35},
+					},
+					NameRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						End:      hcl.Pos{Line: 3, Column: 23, Byte: 35},
+					},
+				},
+				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
+				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
+				Variables:            map[string]module.Variable{},
+				Outputs:              map[string]module.Output{},
+				Filenames:            []string{"test.tf"},
+				ModuleCalls:          map[string]module.DeclaredModuleCall{},
+			},
+			nil,
+		},
+		{
+			"local backend",
+			`
+terraform {
+  backend "local" {
+    path = "relative/path/to/terraform.tfstate"
+  }
+}`,
+			&module.Meta{
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
+				Backend: &module.Backend{
+					Type: "local",
+					Data: &backend.Local{
+						Path: "relative/path/to/terraform.tfstate",
+					},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+						End:      hcl.Pos{Line: 3, Column: 18, Byte: 30},
+					},
+					NameRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						End:      hcl.Pos{Line: 3, Column: 18, Byte: 30},
+					},
+				},
+				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
+				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
+				Variables:            map[string]module.Variable{},
+				Outputs:              map[string]module.Output{},
+				Filenames:            []string{"test.tf"},
+				ModuleCalls:          map[string]module.DeclaredModuleCall{},
+			},
+			nil,
+		},
+		{
+			"untyped backend",
+			`
+terraform {
+  backend "etcdv3" {
+  	blah = "test"
+  }
+}`,
+			&module.Meta{
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
+				Backend: &module.Backend{
+					Type: "etcdv3",
 					Data: &backend.UnknownBackendData{},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
+					NameRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
 				},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -1175,10 +2167,24 @@ terraform {
   backend "remote" {}
 }`,
 			&module.Meta{
-				Path: path,
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				Backend: &module.Backend{
 					Type: "remote",
 					Data: &backend.Remote{},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
+					NameRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
 				},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -1198,10 +2204,24 @@ terraform {
   }
 }`,
 			&module.Meta{
-				Path: path,
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				Backend: &module.Backend{
 					Type: "remote",
 					Data: &backend.Remote{Hostname: "app.example.io"},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
+					NameRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
 				},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -1226,10 +2246,24 @@ terraform {
   }
 }`,
 			&module.Meta{
-				Path: path,
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				Backend: &module.Backend{
 					Type: "remote",
 					Data: &backend.Remote{Hostname: "app.example.io"},
+					DefRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3, Byte: 15},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
+					NameRangePtr: &hcl.Range{
+						Filename: "test.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11, Byte: 23},
+						End:      hcl.Pos{Line: 3, Column: 19, Byte: 31},
+					},
 				},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
@@ -1245,6 +2279,35 @@ terraform {
 	runTestCases(testCases, t, path)
 }
 
+func TestLoadModule_backendVariables(t *testing.T) {
+	src := `
+variable "bucket" {}
+
+terraform {
+  backend "s3" {
+    bucket = var.bucket
+    key    = "network/terraform.tfstate"
+  }
+}
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	meta, diags := LoadModule(t.TempDir(), map[string]*hcl.File{"main.tf": f})
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	expectedData := &backend.S3{
+		Key: "network/terraform.tfstate",
+	}
+	if diff := cmp.Diff(expectedData, meta.Backend.Data); diff != "" {
+		t.Fatalf("backend data mismatch: %s", diff)
+	}
+}
+
 func TestLoadModule_cloud(t *testing.T) {
 	path := t.TempDir()
 
@@ -1259,8 +2322,12 @@ terraform {
 	}
 }`,
 			&module.Meta{
-				Path:    path,
-				Backend: nil,
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
+				Backend:            nil,
 				Cloud: &backend.Cloud{
 					Hostname: "app.example.io",
 				},
@@ -1283,6 +2350,10 @@ terraform {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				Backend:              nil,
 				Cloud:                &backend.Cloud{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
@@ -1306,8 +2377,12 @@ terraform {
 	}
 }`,
 			&module.Meta{
-				Path:    path,
-				Backend: nil,
+				Path:               path,
+				ProviderConfigs:    map[module.ProviderRef]module.ProviderConfig{},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
+				Backend:            nil,
 				Cloud: &backend.Cloud{
 					Hostname: "foo.com",
 				},
@@ -1336,6 +2411,10 @@ module "" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1352,6 +2431,10 @@ module {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1400,6 +2483,10 @@ module "one" "two" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1448,6 +2535,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1462,6 +2553,16 @@ module "name" {
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 3, Column: 2, Byte: 18},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1475,6 +2576,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1491,6 +2596,16 @@ module "name" {
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 4, Column: 2, Byte: 82},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1504,6 +2619,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1519,6 +2638,16 @@ module "name" {
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 4, Column: 2, Byte: 48},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1533,6 +2662,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1550,6 +2683,16 @@ module "name" {
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 5, Column: 2, Byte: 79},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1563,6 +2706,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1579,6 +2726,16 @@ module "name" {
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 4, Column: 2, Byte: 38},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1594,6 +2751,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1607,11 +2768,72 @@ module "name" {
 						InputNames: []string{
 							"one", "two",
 						},
+						Inputs: map[string]module.ModuleCallInput{
+							"one": {
+								Expr: &hclsyntax.TemplateExpr{
+									Parts: []hclsyntax.Expression{
+										&hclsyntax.LiteralValueExpr{
+											Val: cty.StringVal("one"),
+											SrcRange: hcl.Range{
+												Filename: "test.tf",
+												Start:    hcl.Pos{Line: 4, Column: 9, Byte: 45},
+												End:      hcl.Pos{Line: 4, Column: 12, Byte: 48},
+											},
+										},
+									},
+									SrcRange: hcl.Range{
+										Filename: "test.tf",
+										Start:    hcl.Pos{Line: 4, Column: 8, Byte: 44},
+										End:      hcl.Pos{Line: 4, Column: 13, Byte: 49},
+									},
+								},
+								NameRangePtr: &hcl.Range{
+									Filename: "test.tf",
+									Start:    hcl.Pos{Line: 4, Column: 2, Byte: 38},
+									End:      hcl.Pos{Line: 4, Column: 5, Byte: 41},
+								},
+								ValueRangePtr: &hcl.Range{
+									Filename: "test.tf",
+									Start:    hcl.Pos{Line: 4, Column: 8, Byte: 44},
+									End:      hcl.Pos{Line: 4, Column: 13, Byte: 49},
+								},
+							},
+							"two": {
+								Expr: &hclsyntax.LiteralValueExpr{
+									Val: cty.NumberIntVal(42),
+									SrcRange: hcl.Range{
+										Filename: "test.tf",
+										Start:    hcl.Pos{Line: 5, Column: 8, Byte: 57},
+										End:      hcl.Pos{Line: 5, Column: 10, Byte: 59},
+									},
+								},
+								NameRangePtr: &hcl.Range{
+									Filename: "test.tf",
+									Start:    hcl.Pos{Line: 5, Column: 2, Byte: 51},
+									End:      hcl.Pos{Line: 5, Column: 5, Byte: 54},
+								},
+								ValueRangePtr: &hcl.Range{
+									Filename: "test.tf",
+									Start:    hcl.Pos{Line: 5, Column: 8, Byte: 57},
+									End:      hcl.Pos{Line: 5, Column: 10, Byte: 59},
+								},
+							},
+						},
 						RangePtr: &hcl.Range{
 							Filename: "test.tf",
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 6, Column: 2, Byte: 61},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1625,6 +2847,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1641,6 +2867,16 @@ module "name" {
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 4, Column: 2, Byte: 92},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1654,6 +2890,10 @@ module "name" {
 }`,
 			&module.Meta{
 				Path:                 path,
+				ProviderConfigs:      map[module.ProviderRef]module.ProviderConfig{},
+				Resources:            map[string]module.Resource{},
+				DataSources:          map[string]module.Resource{},
+				EphemeralResources:   map[string]module.Resource{},
 				ProviderReferences:   map[module.ProviderRef]tfaddr.Provider{},
 				ProviderRequirements: map[tfaddr.Provider]version.Constraints{},
 				Variables:            map[string]module.Variable{},
@@ -1670,6 +2910,16 @@ module "name" {
 							Start:    hcl.Pos{Line: 2, Column: 15, Byte: 15},
 							End:      hcl.Pos{Line: 4, Column: 2, Byte: 42},
 						},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 8, Byte: 8},
+							End:      hcl.Pos{Line: 2, Column: 14, Byte: 14},
+						},
 					},
 				},
 			},
@@ -1685,6 +2935,37 @@ provider "valid" {
 `,
 			&module.Meta{
 				Path: path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{
+					{LocalName: "-"}: {
+						LocalName: "-",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 13, Byte: 13},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 10, Byte: 10},
+							End:      hcl.Pos{Line: 2, Column: 13, Byte: 13},
+						},
+					},
+					{LocalName: "valid"}: {
+						LocalName: "valid",
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 1, Byte: 18},
+							End:      hcl.Pos{Line: 4, Column: 17, Byte: 34},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 10, Byte: 27},
+							End:      hcl.Pos{Line: 4, Column: 17, Byte: 34},
+						},
+					},
+				},
+				Resources:          map[string]module.Resource{},
+				DataSources:        map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "valid"}: addr.NewLegacyProvider("valid"),
 				},
@@ -1717,7 +2998,73 @@ data "valid_bar" "name" {
 }
 `,
 			&module.Meta{
-				Path: path,
+				Path:            path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{},
+				Resources: map[string]module.Resource{
+					"-invalid_foo.name": {
+						Type:     "-invalid_foo",
+						Name:     "name",
+						Provider: module.ProviderRef{LocalName: "-invalid"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+							End:      hcl.Pos{Line: 2, Column: 31, Byte: 31},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 25, Byte: 25},
+							End:      hcl.Pos{Line: 2, Column: 31, Byte: 31},
+						},
+					},
+					"valid_foo.name": {
+						Type:     "valid_foo",
+						Name:     "name",
+						Provider: module.ProviderRef{LocalName: "valid"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 1, Byte: 36},
+							End:      hcl.Pos{Line: 4, Column: 28, Byte: 63},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 4, Column: 22, Byte: 57},
+							End:      hcl.Pos{Line: 4, Column: 28, Byte: 63},
+						},
+					},
+				},
+				DataSources: map[string]module.Resource{
+					"data.-invalid_bar.name": {
+						Type:     "-invalid_bar",
+						Name:     "name",
+						Provider: module.ProviderRef{LocalName: "-invalid"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 1, Byte: 68},
+							End:      hcl.Pos{Line: 6, Column: 27, Byte: 94},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 6, Column: 21, Byte: 88},
+							End:      hcl.Pos{Line: 6, Column: 27, Byte: 94},
+						},
+					},
+					"data.valid_bar.name": {
+						Type:     "valid_bar",
+						Name:     "name",
+						Provider: module.ProviderRef{LocalName: "valid"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 1, Byte: 99},
+							End:      hcl.Pos{Line: 8, Column: 24, Byte: 122},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 8, Column: 18, Byte: 116},
+							End:      hcl.Pos{Line: 8, Column: 24, Byte: 122},
+						},
+					},
+				},
+				EphemeralResources: map[string]module.Resource{},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "valid"}: addr.NewLegacyProvider("valid"),
 				},
@@ -1749,7 +3096,27 @@ data "valid_bar" "name" {
 	}
 	`,
 			&module.Meta{
-				Path: path,
+				Path:            path,
+				ProviderConfigs: map[module.ProviderRef]module.ProviderConfig{},
+				Resources:       map[string]module.Resource{},
+				DataSources:     map[string]module.Resource{},
+				EphemeralResources: map[string]module.Resource{
+					"ephemeral.ephemeral_resource.test": {
+						Type:     "ephemeral_resource",
+						Name:     "test",
+						Provider: module.ProviderRef{LocalName: "ephemeral"},
+						DefRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 2, Byte: 2},
+							End:      hcl.Pos{Line: 2, Column: 39, Byte: 39},
+						},
+						NameRangePtr: &hcl.Range{
+							Filename: "test.tf",
+							Start:    hcl.Pos{Line: 2, Column: 33, Byte: 33},
+							End:      hcl.Pos{Line: 2, Column: 39, Byte: 39},
+						},
+					},
+				},
 				ProviderReferences: map[module.ProviderRef]tfaddr.Provider{
 					{LocalName: "ephemeral"}: addr.NewLegacyProvider("ephemeral"),
 				},
@@ -1881,29 +3248,94 @@ module "m" {
 			expectedVersion: "1.2.0",
 		},
 		{
-			name: "unresolvable source left untouched",
+			name: "source using functions",
 			cfg: `
-variable "source" {
-  type = string
+variable "env" {
+  default = "PROD"
+}
+locals {
+  base = trimsuffix("./modules/", "/")
 }
 module "m" {
-  source = var.source
+  source = "${local.base}/${lower(var.env)}"
 }`,
-			expectedSource: "",
+			expectedSource: "./modules/prod",
+			expectedAddr:   module.LocalSourceAddr("./modules/prod"),
 		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			f, diags := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
-			if len(diags) > 0 {
-				t.Fatal(diags)
-			}
-
-			meta, diags := LoadModule(path, map[string]*hcl.File{"test.tf": f})
-			if diags.HasErrors() {
-				t.Fatalf("unexpected diagnostics: %s", diags)
-			}
+		{
+			name: "version using functions",
+			cfg: `
+variable "major" {
+  default = 1
+}
+module "m" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = join(".", [var.major, 2, 0])
+}`,
+			expectedSource:  "terraform-aws-modules/vpc/aws",
+			expectedVersion: "1.2.0",
+		},
+		{
+			name: "function available in required version",
+			cfg: `
+terraform {
+  required_version = "~> 1.5.0"
+}
+locals {
+  source = strcontains("vpc", "p") ? "./vpc" : "./other"
+}
+module "m" {
+  source = local.source
+}`,
+			expectedSource: "./vpc",
+			expectedAddr:   module.LocalSourceAddr("./vpc"),
+		},
+		{
+			name: "function unavailable in required version",
+			cfg: `
+terraform {
+  required_version = "~> 1.4.0"
+}
+locals {
+  source = strcontains("vpc", "p") ? "./vpc" : "./other"
+}
+module "m" {
+  source = local.source
+}`,
+			expectedSource: "",
+		},
+		{
+			name: "impure function",
+			cfg: `
+module "m" {
+  source = "./${uuid()}"
+}`,
+			expectedSource: "",
+		},
+		{
+			name: "unresolvable source left untouched",
+			cfg: `
+variable "source" {
+  type = string
+}
+module "m" {
+  source = var.source
+}`,
+			expectedSource: "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(tc.cfg), "test.tf", hcl.InitialPos)
+			if len(diags) > 0 {
+				t.Fatal(diags)
+			}
+
+			meta, diags := LoadModule(path, map[string]*hcl.File{"test.tf": f})
+			if diags.HasErrors() {
+				t.Fatalf("unexpected diagnostics: %s", diags)
+			}
 
 			mc, ok := meta.ModuleCalls["m"]
 			if !ok {
@@ -1929,3 +3361,735 @@ module "m" {
 		})
 	}
 }
+
+func TestLoadModule_tofuFilePrecedence(t *testing.T) {
+	path := t.TempDir()
+
+	files := map[string]string{
+		"main.tf": `
+variable "name" {
+  default = "from-tf"
+}
+module "net" {
+  source = "./net-tf"
+}`,
+		"main.tofu": `
+variable "name" {
+  default = "from-tofu"
+}
+module "net" {
+  source = "./net-tofu"
+}`,
+		"outputs.tf.json":   `{"output": {"legacy": {"value": "tf"}}}`,
+		"outputs.tofu.json": `{"output": {"current": {"value": "tofu"}}}`,
+		"providers.tf": `
+provider "aws" {}
+`,
+	}
+
+	parsedFiles := make(map[string]*hcl.File, len(files))
+	for name, src := range files {
+		var f *hcl.File
+		var diags hcl.Diagnostics
+		if strings.HasSuffix(name, ".json") {
+			f, diags = json.Parse([]byte(src), name)
+		} else {
+			f, diags = hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		}
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		parsedFiles[name] = f
+	}
+
+	meta, diags := LoadModule(path, parsedFiles)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	expectedFilenames := []string{"main.tofu", "outputs.tofu.json", "providers.tf"}
+	if diff := cmp.Diff(expectedFilenames, meta.Filenames); diff != "" {
+		t.Fatalf("filenames mismatch: %s", diff)
+	}
+	expectedShadowed := []string{"main.tf", "outputs.tf.json"}
+	if diff := cmp.Diff(expectedShadowed, meta.ShadowedFilenames); diff != "" {
+		t.Fatalf("shadowed filenames mismatch: %s", diff)
+	}
+
+	if diff := cmp.Diff(cty.StringVal("from-tofu"), meta.Variables["name"].DefaultValue, customComparer...); diff != "" {
+		t.Fatalf("variable mismatch: %s", diff)
+	}
+	if meta.ModuleCalls["net"].RawSourceAddr != "./net-tofu" {
+		t.Fatalf("expected module source from main.tofu, got %q", meta.ModuleCalls["net"].RawSourceAddr)
+	}
+	if _, ok := meta.Outputs["legacy"]; ok {
+		t.Fatal("expected output from shadowed outputs.tf.json to be ignored")
+	}
+	if _, ok := meta.Outputs["current"]; !ok {
+		t.Fatal("expected output from outputs.tofu.json")
+	}
+}
+
+func TestLoadModule_overrideFiles(t *testing.T) {
+	path := t.TempDir()
+
+	files := map[string]string{
+		"main.tf": `
+terraform {
+  required_version = ">= 1.6"
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 4.0"
+    }
+  }
+  backend "s3" {}
+}
+
+provider "aws" {
+  alias   = "west"
+  version = "4.1.0"
+}
+
+variable "region" {
+  type        = string
+  description = "AWS region"
+  default     = "eu-west-1"
+}
+
+variable "replicas" {
+  default = "3"
+}
+
+output "region" {
+  description = "Region"
+  value       = "eu-west-1"
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+  cidr    = "10.0.0.0/16"
+}
+
+resource "aws_instance" "web" {}
+`,
+		"override.tf": `
+terraform {
+  required_version = ">= 1.8"
+  backend "local" {}
+}
+
+variable "region" {
+  default = "us-east-1"
+}
+
+variable "replicas" {
+  type = number
+}
+
+output "region" {
+  sensitive = true
+}
+
+module "vpc" {
+  version = "5.1.0"
+  name    = "main"
+}
+
+resource "aws_instance" "web" {
+  provider = aws.west
+}
+`,
+		"providers_override.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+provider "aws" {
+  alias   = "west"
+  version = "5.1.0"
+}
+`,
+	}
+
+	parsedFiles := make(map[string]*hcl.File, len(files))
+	for name, src := range files {
+		f, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		parsedFiles[name] = f
+	}
+
+	meta, diags := LoadModule(path, parsedFiles)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	expectedCore := version.MustConstraints(version.NewConstraint(">= 1.8"))
+	if diff := cmp.Diff(expectedCore, meta.CoreRequirements, customComparer...); diff != "" {
+		t.Fatalf("core requirements mismatch: %s", diff)
+	}
+
+	if meta.Backend == nil || meta.Backend.Type != "local" {
+		t.Fatalf("expected overridden local backend, got %#v", meta.Backend)
+	}
+
+	awsAddr := tfaddr.MustParseProviderSource("hashicorp/aws")
+	expectedAwsCons := version.MustConstraints(version.NewConstraint("~> 5.0, 5.1.0"))
+	if diff := cmp.Diff(expectedAwsCons, meta.ProviderRequirements[awsAddr], customComparer...); diff != "" {
+		t.Fatalf("provider requirements mismatch: %s", diff)
+	}
+
+	expectedVariables := map[string]module.Variable{
+		"region": {
+			Type:         cty.String,
+			Description:  "AWS region",
+			DefaultValue: cty.StringVal("us-east-1"),
+			DefRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 18, Column: 1, Byte: 225},
+				End:      hcl.Pos{Line: 18, Column: 18, Byte: 242},
+			},
+			NameRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 18, Column: 10, Byte: 234},
+				End:      hcl.Pos{Line: 18, Column: 18, Byte: 242},
+			},
+			IsNullable: true,
+		},
+		"replicas": {
+			Type:         cty.Number,
+			DefaultValue: cty.NumberIntVal(3),
+			DefRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 24, Column: 1, Byte: 328},
+				End:      hcl.Pos{Line: 24, Column: 20, Byte: 347},
+			},
+			NameRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 24, Column: 10, Byte: 337},
+				End:      hcl.Pos{Line: 24, Column: 20, Byte: 347},
+			},
+			IsNullable: true,
+		},
+	}
+	if diff := cmp.Diff(expectedVariables, meta.Variables, customComparer...); diff != "" {
+		t.Fatalf("variables mismatch: %s", diff)
+	}
+
+	expectedOutputs := map[string]module.Output{
+		"region": {
+			Description: "Region",
+			IsSensitive: true,
+			Value:       cty.StringVal("eu-west-1"),
+			Type:        cty.String,
+			DefRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 28, Column: 1, Byte: 369},
+				End:      hcl.Pos{Line: 28, Column: 16, Byte: 384},
+			},
+			NameRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 28, Column: 8, Byte: 376},
+				End:      hcl.Pos{Line: 28, Column: 16, Byte: 384},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedOutputs, meta.Outputs, customComparer...); diff != "" {
+		t.Fatalf("outputs mismatch: %s", diff)
+	}
+
+	vpc := meta.ModuleCalls["vpc"]
+	if vpc.RawSourceAddr != "terraform-aws-modules/vpc/aws" {
+		t.Fatalf("unexpected module source: %q", vpc.RawSourceAddr)
+	}
+	expectedVersion := version.MustConstraints(version.NewConstraint("5.1.0"))
+	if diff := cmp.Diff(expectedVersion, vpc.Version, customComparer...); diff != "" {
+		t.Fatalf("module version mismatch: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"cidr", "name"}, vpc.InputNames); diff != "" {
+		t.Fatalf("module inputs mismatch: %s", diff)
+	}
+
+	westRef := module.ProviderRef{LocalName: "aws", Alias: "west"}
+	if _, ok := meta.ProviderReferences[westRef]; !ok {
+		t.Fatalf("expected %#v provider reference", westRef)
+	}
+}
+
+func TestLoadModule_overrideMissingBase(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `
+variable "known" {}
+`,
+		"main_override.tf": `
+variable "unknown" {
+  default = "foo"
+}
+
+output "unknown" {
+  value = "foo"
+}
+
+module "unknown" {
+  source = "./foo"
+}
+
+resource "aws_instance" "unknown" {}
+
+locals {
+  unknown = "foo"
+}
+`,
+	}
+
+	parsedFiles := make(map[string]*hcl.File, len(files))
+	for name, src := range files {
+		f, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		parsedFiles[name] = f
+	}
+
+	meta, diags := LoadModule(t.TempDir(), parsedFiles)
+
+	expectedSummaries := []string{
+		"Missing base variable declaration to override",
+		"Missing base output definition to override",
+		"Missing module call to override",
+		"Missing resource to override",
+		"Missing base local value definition to override",
+	}
+	summaries := make([]string, 0, len(diags))
+	for _, diag := range diags {
+		summaries = append(summaries, diag.Summary)
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("diagnostics mismatch: %s", diff)
+	}
+
+	if _, ok := meta.Variables["unknown"]; ok {
+		t.Fatal("expected overriding variable without base to be ignored")
+	}
+	if _, ok := meta.ModuleCalls["unknown"]; ok {
+		t.Fatal("expected overriding module call without base to be ignored")
+	}
+}
+
+func TestLoadModule_moduleCallUnexpectedBlock(t *testing.T) {
+	src := `
+module "vpc" {
+  source = "./vpc"
+  cidr   = "10.0.0.0/16"
+
+  unexpected {}
+}
+`
+	f, pDiags := hclsyntax.ParseConfig([]byte(src), "main.tf", hcl.InitialPos)
+	if len(pDiags) > 0 {
+		t.Fatal(pDiags)
+	}
+
+	meta, diags := LoadModule(t.TempDir(), map[string]*hcl.File{"main.tf": f})
+	if !diags.HasErrors() {
+		t.Fatal("expected error for unexpected block")
+	}
+
+	mc, ok := meta.ModuleCalls["vpc"]
+	if !ok {
+		t.Fatal("expected module call to be decoded")
+	}
+	if diff := cmp.Diff([]string{"cidr"}, mc.InputNames); diff != "" {
+		t.Fatalf("input names mismatch: %s", diff)
+	}
+	if _, ok := mc.Inputs["cidr"]; !ok {
+		t.Fatal("expected input to be decoded despite the unexpected block")
+	}
+}
+
+func TestLoadModule_resources(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `
+resource "aws_instance" "web" {
+  count = 2
+}
+
+data "aws_ami" "ubuntu" {
+  for_each = toset(["a", "b"])
+  provider = aws.west
+}
+
+ephemeral "random_password" "db" {}
+`,
+		"main_override.tf": `
+resource "aws_instance" "web" {
+  for_each = var.instances
+}
+`,
+	}
+
+	parsedFiles := make(map[string]*hcl.File, len(files))
+	for name, src := range files {
+		f, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		parsedFiles[name] = f
+	}
+
+	meta, diags := LoadModule(t.TempDir(), parsedFiles)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	expectedResources := map[string]module.Resource{
+		"aws_instance.web": {
+			Type:       "aws_instance",
+			Name:       "web",
+			Provider:   module.ProviderRef{LocalName: "aws"},
+			HasForEach: true,
+			DefRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+				End:      hcl.Pos{Line: 2, Column: 30, Byte: 30},
+			},
+			NameRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 2, Column: 25, Byte: 25},
+				End:      hcl.Pos{Line: 2, Column: 30, Byte: 30},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedResources, meta.Resources); diff != "" {
+		t.Fatalf("resources mismatch: %s", diff)
+	}
+
+	expectedDataSources := map[string]module.Resource{
+		"data.aws_ami.ubuntu": {
+			Type:       "aws_ami",
+			Name:       "ubuntu",
+			Provider:   module.ProviderRef{LocalName: "aws", Alias: "west"},
+			HasForEach: true,
+			DefRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 6, Column: 1, Byte: 48},
+				End:      hcl.Pos{Line: 6, Column: 24, Byte: 71},
+			},
+			NameRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 6, Column: 16, Byte: 63},
+				End:      hcl.Pos{Line: 6, Column: 24, Byte: 71},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDataSources, meta.DataSources); diff != "" {
+		t.Fatalf("data sources mismatch: %s", diff)
+	}
+
+	expectedEphemeralResources := map[string]module.Resource{
+		"ephemeral.random_password.db": {
+			Type:     "random_password",
+			Name:     "db",
+			Provider: module.ProviderRef{LocalName: "random"},
+			DefRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 11, Column: 1, Byte: 130},
+				End:      hcl.Pos{Line: 11, Column: 33, Byte: 162},
+			},
+			NameRangePtr: &hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 11, Column: 29, Byte: 158},
+				End:      hcl.Pos{Line: 11, Column: 33, Byte: 162},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedEphemeralResources, meta.EphemeralResources); diff != "" {
+		t.Fatalf("ephemeral resources mismatch: %s", diff)
+	}
+}
+
+func TestLoadModule_moduleCallArguments(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `
+module "net" {
+  source = "./net"
+  count  = 2
+
+  providers = {
+    aws      = aws.west
+    aws.east = aws
+  }
+  depends_on = [aws_instance.web, module.db]
+
+  cidr = "10.0.0.0/16"
+}
+
+module "db" {
+  source   = "./db"
+  for_each = toset(["a", "b"])
+}
+`,
+		"main_override.tf": `
+module "db" {
+  count      = 1
+  depends_on = [module.net[0]]
+}
+`,
+	}
+
+	parsedFiles := make(map[string]*hcl.File, len(files))
+	for name, src := range files {
+		f, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		parsedFiles[name] = f
+	}
+
+	meta, diags := LoadModule(t.TempDir(), parsedFiles)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	type moduleCallArguments struct {
+		InputNames []string
+		Providers  map[module.ProviderRef]module.ProviderRef
+		HasCount   bool
+		HasForEach bool
+		DependsOn  []string
+	}
+	expectedArguments := map[string]moduleCallArguments{
+		"net": {
+			InputNames: []string{"cidr"},
+			Providers: map[module.ProviderRef]module.ProviderRef{
+				{LocalName: "aws"}:                {LocalName: "aws", Alias: "west"},
+				{LocalName: "aws", Alias: "east"}: {LocalName: "aws"},
+			},
+			HasCount:  true,
+			DependsOn: []string{"aws_instance.web", "module.db"},
+		},
+		"db": {
+			InputNames: []string{},
+			HasCount:   true,
+			DependsOn:  []string{"module.net[0]"},
+		},
+	}
+
+	arguments := make(map[string]moduleCallArguments, len(meta.ModuleCalls))
+	for name, mc := range meta.ModuleCalls {
+		arguments[name] = moduleCallArguments{
+			InputNames: mc.InputNames,
+			Providers:  mc.Providers,
+			HasCount:   mc.HasCount,
+			HasForEach: mc.HasForEach,
+			DependsOn:  mc.DependsOn,
+		}
+	}
+	if diff := cmp.Diff(expectedArguments, arguments); diff != "" {
+		t.Fatalf("module call arguments mismatch: %s", diff)
+	}
+}
+
+func TestLoadModule_invalidModuleProviders(t *testing.T) {
+	cfg := `
+module "net" {
+  source = "./net"
+
+  providers = {
+    aws     = aws.west
+    aws     = aws.east
+    "aws.x" = aws
+  }
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	meta, diags := LoadModule(t.TempDir(), map[string]*hcl.File{"main.tf": f})
+	summaries := make([]string, 0)
+	for _, diag := range diags {
+		summaries = append(summaries, diag.Summary)
+	}
+	expectedSummaries := []string{"Duplicate provider address", "Invalid expression"}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("diagnostics mismatch: %s", diff)
+	}
+
+	expectedProviders := map[module.ProviderRef]module.ProviderRef{
+		{LocalName: "aws"}: {LocalName: "aws", Alias: "west"},
+	}
+	if diff := cmp.Diff(expectedProviders, meta.ModuleCalls["net"].Providers); diff != "" {
+		t.Fatalf("providers mismatch: %s", diff)
+	}
+}
+
+func TestLoadModule_variableValidations(t *testing.T) {
+	cfg := `
+variable "name" {
+  type      = string
+  nullable  = false
+  ephemeral = true
+
+  validation {
+    condition     = length(var.name) > 3
+    error_message = "The name must be longer than 3 characters."
+  }
+
+  validation {
+    condition     = can(regex("^[a-z]+$", var.name))
+    error_message = "The name must contain only lowercase letters, ${var.name} given."
+  }
+}
+
+variable "plain" {}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	meta, diags := LoadModule(t.TempDir(), map[string]*hcl.File{"test.tf": f})
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	plain := meta.Variables["plain"]
+	if !plain.IsNullable || plain.IsEphemeral || plain.Validations != nil {
+		t.Fatalf("unexpected defaults for plain variable: %#v", plain)
+	}
+
+	v := meta.Variables["name"]
+	if v.IsNullable {
+		t.Fatal("expected variable not to be nullable")
+	}
+	if !v.IsEphemeral {
+		t.Fatal("expected variable to be ephemeral")
+	}
+	if len(v.Validations) != 2 {
+		t.Fatalf("expected 2 validations, %d given", len(v.Validations))
+	}
+
+	expectedConditions := []string{
+		"length(var.name) > 3",
+		`can(regex("^[a-z]+$", var.name))`,
+	}
+	for i, validation := range v.Validations {
+		condition := string(validation.Condition.Range().SliceBytes([]byte(cfg)))
+		if condition != expectedConditions[i] {
+			t.Fatalf("unexpected condition %d: %q", i, condition)
+		}
+	}
+
+	msg, msgDiags := v.Validations[0].ErrorMessage.Value(nil)
+	if msgDiags.HasErrors() {
+		t.Fatal(msgDiags)
+	}
+	if msg.AsString() != "The name must be longer than 3 characters." {
+		t.Fatalf("unexpected error message: %q", msg.AsString())
+	}
+
+	expectedRange := &hcl.Range{
+		Filename: "test.tf",
+		Start:    hcl.Pos{Line: 7, Column: 3, Byte: 82},
+		End:      hcl.Pos{Line: 7, Column: 13, Byte: 92},
+	}
+	if diff := cmp.Diff(expectedRange, v.Validations[0].DefRangePtr); diff != "" {
+		t.Fatalf("unexpected validation range: %s", diff)
+	}
+}
+
+func TestLoadModule_outputs(t *testing.T) {
+	cfg := `
+variable "env" {
+  default = "prod"
+}
+
+variable "count" {
+  type = number
+}
+
+locals {
+  prefix = "app-${var.env}"
+}
+
+output "from_default" {
+  value = var.env
+}
+
+output "from_local" {
+  value = "${local.prefix}-web"
+}
+
+output "unknown_typed" {
+  value = var.count + 1
+}
+
+output "from_resource" {
+  value      = aws_instance.web.id
+  ephemeral  = true
+  depends_on = [aws_instance.web, module.net["a"]]
+
+  precondition {
+    condition     = aws_instance.web.id != ""
+    error_message = "Instance must exist."
+  }
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "test.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	meta, diags := LoadModule(t.TempDir(), map[string]*hcl.File{"test.tf": f})
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	testCases := []struct {
+		name          string
+		expectedValue cty.Value
+		expectedType  cty.Type
+	}{
+		{"from_default", cty.StringVal("prod"), cty.String},
+		{"from_local", cty.StringVal("app-prod-web"), cty.String},
+		{"unknown_typed", cty.NilVal, cty.Number},
+		{"from_resource", cty.NilVal, cty.DynamicPseudoType},
+	}
+	for _, tc := range testCases {
+		output := meta.Outputs[tc.name]
+		if diff := cmp.Diff(tc.expectedValue, output.Value, ctydebug.CmpOptions); diff != "" {
+			t.Fatalf("unexpected value of %q: %s", tc.name, diff)
+		}
+		if !tc.expectedType.Equals(output.Type) {
+			t.Fatalf("unexpected type of %q: %#v", tc.name, output.Type)
+		}
+	}
+
+	output := meta.Outputs["from_resource"]
+	if !output.IsEphemeral {
+		t.Fatal("expected output to be ephemeral")
+	}
+	if diff := cmp.Diff([]string{"aws_instance.web", `module.net["a"]`}, output.DependsOn); diff != "" {
+		t.Fatalf("unexpected depends_on: %s", diff)
+	}
+	if len(output.Preconditions) != 1 {
+		t.Fatalf("expected 1 precondition, %d given", len(output.Preconditions))
+	}
+	msg, msgDiags := output.Preconditions[0].ErrorMessage.Value(nil)
+	if msgDiags.HasErrors() {
+		t.Fatal(msgDiags)
+	}
+	if msg.AsString() != "Instance must exist." {
+		t.Fatalf("unexpected error message: %q", msg.AsString())
+	}
+}
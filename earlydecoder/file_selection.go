@@ -0,0 +1,60 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"sort"
+	"strings"
+)
+
+// tofuFileExtensions maps OpenTofu-specific file extensions to the
+// Terraform-compatible extensions they take precedence over.
+var tofuFileExtensions = map[string]string{
+	".tofu":      ".tf",
+	".tofu.json": ".tf.json",
+}
+
+// selectModuleFiles applies the same file selection rules as OpenTofu,
+// i.e. a file with a .tofu (or .tofu.json) extension takes precedence
+// over a file with the same name and .tf (or .tf.json) extension,
+// which is then ignored.
+//
+// It returns sorted lists of selected and shadowed filenames, where the
+// latter is nil if no file was shadowed.
+func selectModuleFiles(filenames []string) ([]string, []string) {
+	present := make(map[string]struct{}, len(filenames))
+	for _, filename := range filenames {
+		present[filename] = struct{}{}
+	}
+
+	selected := make([]string, 0, len(filenames))
+	var shadowed []string
+	for _, filename := range filenames {
+		if isShadowedByTofuFile(filename, present) {
+			shadowed = append(shadowed, filename)
+			continue
+		}
+		selected = append(selected, filename)
+	}
+
+	sort.Strings(selected)
+	sort.Strings(shadowed)
+
+	return selected, shadowed
+}
+
+func isShadowedByTofuFile(filename string, present map[string]struct{}) bool {
+	for tofuExt, tfExt := range tofuFileExtensions {
+		if !strings.HasSuffix(filename, tfExt) {
+			continue
+		}
+		tofuFilename := strings.TrimSuffix(filename, tfExt) + tofuExt
+		if _, ok := present[tofuFilename]; ok {
+			return true
+		}
+	}
+	return false
+}
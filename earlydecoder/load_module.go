@@ -7,13 +7,16 @@ package earlydecoder
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/addrs"
 	"github.com/opentofu/opentofu-schema/backend"
 	"github.com/opentofu/opentofu-schema/module"
 	"github.com/zclconf/go-cty/cty"
@@ -33,11 +36,17 @@ type decodedModule struct {
 	Variables            map[string]*module.Variable
 	Outputs              map[string]*module.Output
 	ModuleCalls          map[string]*module.DeclaredModuleCall
+	Moved                []module.Moved
+	Imports              []module.Import
+	Removed              []module.Removed
 
 	// Expressions for us to handle and evaluate for module source/version
 	localExprs         map[string]hcl.Expression
 	moduleSourceExprs  map[string]hcl.Expression
 	moduleVersionExprs map[string]hcl.Expression
+	outputValueExprs   map[string]hcl.Expression
+
+	backendRanges map[string]declRange
 }
 
 func newDecodedModule() *decodedModule {
@@ -56,24 +65,62 @@ func newDecodedModule() *decodedModule {
 		localExprs:         make(map[string]hcl.Expression),
 		moduleSourceExprs:  make(map[string]hcl.Expression),
 		moduleVersionExprs: make(map[string]hcl.Expression),
+		outputValueExprs:   make(map[string]hcl.Expression),
+
+		backendRanges: make(map[string]declRange),
 	}
 }
 
 // providerConfig represents a provider block in the configuration
 type providerConfig struct {
-	Name  string
-	Alias string
+	Name    string
+	Alias   string
+	Version string
+
+	DefRange  hcl.Range
+	NameRange hcl.Range
+}
+
+// declRange holds the ranges of a single declaration,
+// i.e. of a block header or of an attribute
+type declRange struct {
+	DefRange  hcl.Range
+	NameRange hcl.Range
+}
+
+// blockDeclRange returns ranges of the given block, where the name
+// is represented by the label at given index
+func blockDeclRange(block *hcl.Block, labelIdx int) declRange {
+	return declRange{
+		DefRange:  block.DefRange,
+		NameRange: block.LabelRanges[labelIdx],
+	}
 }
 
 // loadModuleFromFile reads given file, interprets it and stores in given Module
 // This is useful for any caller which does tokenization/parsing on its own
 // e.g. because it will reuse these parsed files later for more detailed
 // interpretation.
-func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
+//
+// Override files (see module.IsOverrideFile) must be loaded after all primary files
+// with isOverride set to true, in which case their blocks are merged into
+// the ones already declared, following OpenTofu's override rules.
+func loadModuleFromFile(file *hcl.File, mod *decodedModule, isOverride bool) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 	content, _, contentDiags := file.Body.PartialContent(rootSchema)
 	diags = append(diags, contentDiags...)
 
+	// Core requirements from an override file replace all the ones
+	// declared previously, but multiple ones within the same file combine.
+	coreRequirementsOverridden := false
+	addCoreRequirement := func(constraint string) {
+		if isOverride && !coreRequirementsOverridden {
+			mod.RequiredCore = make([]string, 0)
+			coreRequirementsOverridden = true
+		}
+		mod.RequiredCore = append(mod.RequiredCore, constraint)
+	}
+
 	for _, block := range content.Blocks {
 		switch block.Type {
 
@@ -96,7 +143,7 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 					valDiags := gohcl.DecodeExpression(attr.Expr, nil, &version)
 					diags = append(diags, valDiags...)
 					if !valDiags.HasErrors() {
-						mod.RequiredCore = append(mod.RequiredCore, version)
+						addCoreRequirement(version)
 					}
 				}
 			}
@@ -110,7 +157,7 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 				valDiags := gohcl.DecodeExpression(attr.Expr, nil, &version)
 				diags = append(diags, valDiags...)
 				if !valDiags.HasErrors() {
-					mod.RequiredCore = append(mod.RequiredCore, version)
+					addCoreRequirement(version)
 				}
 			}
 
@@ -120,12 +167,29 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 					data, bDiags := decodeCloudBlock(innerBlock)
 					diags = append(diags, bDiags...)
 					mod.CloudBackend = data
+					if isOverride {
+						// cloud block replaces any backend declared previously
+						mod.Backends = make(map[string]backend.BackendData)
+					}
 				case "backend":
 					bType := innerBlock.Labels[0]
 
 					data, bDiags := decodeBackendsBlock(innerBlock)
 					diags = append(diags, bDiags...)
 
+					if isOverride {
+						// backend block replaces any backend or cloud block
+						// declared previously, regardless of its type
+						mod.Backends = map[string]backend.BackendData{
+							bType: data,
+						}
+						mod.backendRanges = map[string]declRange{
+							bType: blockDeclRange(innerBlock, 0),
+						}
+						mod.CloudBackend = nil
+						continue
+					}
+
 					if _, exists := mod.Backends[bType]; exists {
 						diags = append(diags, &hcl.Diagnostic{
 							Severity: hcl.DiagError,
@@ -137,12 +201,16 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 					}
 
 					mod.Backends[bType] = data
+					mod.backendRanges[bType] = blockDeclRange(innerBlock, 0)
 				case "required_providers":
 					reqs, reqsDiags := decodeRequiredProvidersBlock(innerBlock)
 					diags = append(diags, reqsDiags...)
 					for name, req := range reqs {
 						if _, exists := mod.ProviderRequirements[name]; !exists {
 							mod.ProviderRequirements[name] = req
+						} else if isOverride {
+							// overridden requirements replace the original ones
+							mod.ProviderRequirements[name] = req
 						} else {
 							if req.Source != "" {
 								source := mod.ProviderRequirements[name].Source
@@ -159,6 +227,7 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 							}
 
 							mod.ProviderRequirements[name].VersionConstraints = append(mod.ProviderRequirements[name].VersionConstraints, req.VersionConstraints...)
+							mod.ProviderRequirements[name].Declarations = append(mod.ProviderRequirements[name].Declarations, req.Declarations...)
 						}
 					}
 				}
@@ -168,19 +237,6 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 			diags = append(diags, contentDiags...)
 
 			name := block.Labels[0]
-			// Even if there isn't an explicit version required, we still
-			// need an entry in our map to signal the unversioned dependency.
-			if _, exists := mod.ProviderRequirements[name]; !exists {
-				mod.ProviderRequirements[name] = &providerRequirement{}
-			}
-			if attr, defined := content.Attributes["version"]; defined {
-				var version string
-				valDiags := gohcl.DecodeExpression(attr.Expr, nil, &version)
-				diags = append(diags, valDiags...)
-				if !valDiags.HasErrors() {
-					mod.ProviderRequirements[name].VersionConstraints = append(mod.ProviderRequirements[name].VersionConstraints, version)
-				}
-			}
 
 			providerKey := name
 			var alias string
@@ -192,9 +248,43 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 				}
 			}
 
-			mod.ProviderConfigs[providerKey] = &providerConfig{
-				Name:  name,
-				Alias: alias,
+			pc, exists := mod.ProviderConfigs[providerKey]
+			if isOverride && !exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing base provider configuration for override",
+					Detail:   fmt.Sprintf("There is no %s provider configuration with the alias %q. An override file can only override an aliased provider configuration that was already defined in a primary configuration file.", name, alias),
+					Subject:  &block.DefRange,
+				})
+				continue
+			}
+			if !isOverride {
+				pc = &providerConfig{
+					Name:      name,
+					Alias:     alias,
+					DefRange:  block.DefRange,
+					NameRange: block.LabelRanges[0],
+				}
+				mod.ProviderConfigs[providerKey] = pc
+			}
+
+			// Even if there isn't an explicit version required, we still
+			// need an entry in our map to signal the unversioned dependency.
+			if _, exists := mod.ProviderRequirements[name]; !exists {
+				mod.ProviderRequirements[name] = &providerRequirement{}
+			}
+			if attr, defined := content.Attributes["version"]; defined {
+				var version string
+				valDiags := gohcl.DecodeExpression(attr.Expr, nil, &version)
+				diags = append(diags, valDiags...)
+				if !valDiags.HasErrors() {
+					req := mod.ProviderRequirements[name]
+					if isOverride && pc.Version != "" {
+						req.VersionConstraints = removeFirstString(req.VersionConstraints, pc.Version)
+					}
+					req.VersionConstraints = append(req.VersionConstraints, version)
+					pc.Version = version
+				}
 			}
 
 		case "data":
@@ -202,8 +292,22 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 			diags = append(diags, contentDiags...)
 
 			ds := &dataSource{
-				Type: block.Labels[0],
-				Name: block.Labels[1],
+				Type:      block.Labels[0],
+				Name:      block.Labels[1],
+				DefRange:  block.DefRange,
+				NameRange: block.LabelRanges[1],
+			}
+			ds.HasCount, ds.HasForEach = decodeRepetitionArguments(content)
+
+			if isOverride {
+				var base *resourceOverrideTarget
+				if existing, exists := mod.DataSources[ds.MapKey()]; exists {
+					base = existing.overrideTarget()
+				}
+				diags = append(diags, mergeResourceOverride(block, content, base,
+					"Missing data resource to override",
+					fmt.Sprintf("There is no data %q %q block defined. An override file can only override a data block defined in a primary configuration file.", ds.Type, ds.Name))...)
+				continue
 			}
 
 			mod.DataSources[ds.MapKey()] = ds
@@ -225,8 +329,22 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 			diags = append(diags, contentDiags...)
 
 			r := &resource{
-				Type: block.Labels[0],
-				Name: block.Labels[1],
+				Type:      block.Labels[0],
+				Name:      block.Labels[1],
+				DefRange:  block.DefRange,
+				NameRange: block.LabelRanges[1],
+			}
+			r.HasCount, r.HasForEach = decodeRepetitionArguments(content)
+
+			if isOverride {
+				var base *resourceOverrideTarget
+				if existing, exists := mod.Resources[r.MapKey()]; exists {
+					base = existing.overrideTarget()
+				}
+				diags = append(diags, mergeResourceOverride(block, content, base,
+					"Missing resource to override",
+					fmt.Sprintf("There is no %s resource named %q. An override file can only override a resource block defined in a primary configuration file.", r.Type, r.Name))...)
+				continue
 			}
 
 			mod.Resources[r.MapKey()] = r
@@ -249,10 +367,24 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 
 			er := &ephemeralResource{
 				resource: resource{
-					Type: block.Labels[0],
-					Name: block.Labels[1],
+					Type:      block.Labels[0],
+					Name:      block.Labels[1],
+					DefRange:  block.DefRange,
+					NameRange: block.LabelRanges[1],
 				},
 			}
+			er.HasCount, er.HasForEach = decodeRepetitionArguments(content)
+
+			if isOverride {
+				var base *resourceOverrideTarget
+				if existing, exists := mod.EphemeralResources[er.MapKey()]; exists {
+					base = existing.overrideTarget()
+				}
+				diags = append(diags, mergeResourceOverride(block, content, base,
+					"Missing ephemeral resource to override",
+					fmt.Sprintf("There is no ephemeral %q %q block defined. An override file can only override an ephemeral block defined in a primary configuration file.", er.Type, er.Name))...)
+				continue
+			}
 
 			mod.EphemeralResources[er.MapKey()] = er
 
@@ -274,56 +406,78 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 				continue
 			}
 			name := block.Labels[0]
-			description := ""
-			isSensitive := false
+
+			v, exists := mod.Variables[name]
+			if isOverride && !exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing base variable declaration to override",
+					Detail:   fmt.Sprintf("There is no variable named %q. An override file can only override a variable that was already declared in a primary configuration file.", name),
+					Subject:  &block.DefRange,
+				})
+				continue
+			}
+			if !isOverride {
+				v = &module.Variable{
+					Type:         cty.DynamicPseudoType,
+					DefaultValue: cty.NilVal,
+					IsNullable:   true,
+					DefRangePtr:  block.DefRange.Ptr(),
+					NameRangePtr: block.LabelRanges[0].Ptr(),
+				}
+				mod.Variables[name] = v
+			}
+
 			var valDiags hcl.Diagnostics
 			if attr, defined := content.Attributes["description"]; defined {
-				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &description)
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &v.Description)
 				diags = append(diags, valDiags...)
 			}
-			varType := cty.DynamicPseudoType
-			var defaults *typeexpr.Defaults
-			if attr, defined := content.Attributes["type"]; defined {
-				varType, defaults, valDiags = typeexpr.TypeConstraintWithDefaults(attr.Expr)
+			typeAttr, typeDefined := content.Attributes["type"]
+			if typeDefined {
+				v.Type, v.TypeDefaults, valDiags = typeexpr.TypeConstraintWithDefaults(typeAttr.Expr)
 				diags = append(diags, valDiags...)
 			}
 			if attr, defined := content.Attributes["sensitive"]; defined {
-				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &isSensitive)
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &v.IsSensitive)
 				diags = append(diags, valDiags...)
 			}
-			defaultValue := cty.NilVal
 			if attr, defined := content.Attributes["default"]; defined {
-				val, diags := attr.Expr.Value(nil)
-				if !diags.HasErrors() {
-					if varType != cty.NilType {
-						var err error
-						val, err = convert.Convert(val, varType)
-						if err != nil {
-							diags = append(diags, &hcl.Diagnostic{
-								Severity: hcl.DiagError,
-								Summary:  "Invalid default value for variable",
-								Detail:   fmt.Sprintf("This default value is not compatible with the variable's type constraint: %s.", err),
-								Subject:  attr.Expr.Range().Ptr(),
-							})
-							val = cty.DynamicVal
-						}
-					}
-
-					defaultValue = val
+				val, vDiags := attr.Expr.Value(nil)
+				if !vDiags.HasErrors() {
+					v.DefaultValue, valDiags = convertVariableDefault(val, v.Type, attr.Expr.Range())
+					diags = append(diags, valDiags...)
 				}
+			} else if typeDefined && v.DefaultValue != cty.NilVal {
+				// An overridden type constraint applies to the original default
+				v.DefaultValue, valDiags = convertVariableDefault(v.DefaultValue, v.Type, typeAttr.Expr.Range())
+				diags = append(diags, valDiags...)
 			}
-			deprecated := ""
 			if attr, defined := content.Attributes["deprecated"]; defined {
-				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &deprecated)
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &v.Deprecated)
+				diags = append(diags, valDiags...)
+			}
+			if attr, defined := content.Attributes["nullable"]; defined {
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &v.IsNullable)
+				diags = append(diags, valDiags...)
+			}
+			if attr, defined := content.Attributes["ephemeral"]; defined {
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &v.IsEphemeral)
 				diags = append(diags, valDiags...)
 			}
-			mod.Variables[name] = &module.Variable{
-				Type:         varType,
-				Description:  description,
-				IsSensitive:  isSensitive,
-				DefaultValue: defaultValue,
-				TypeDefaults: defaults,
-				Deprecated:   deprecated,
+
+			var validations []module.CheckRule
+			for _, innerBlock := range content.Blocks {
+				if innerBlock.Type != "validation" {
+					continue
+				}
+				validation, vDiags := decodeCheckRuleBlock(innerBlock)
+				diags = append(diags, vDiags...)
+				validations = append(validations, validation)
+			}
+			// Validation rules from an override file replace the original ones
+			if len(validations) > 0 || !isOverride {
+				v.Validations = validations
 			}
 		case "output":
 			content, _, contentDiags := block.Body.PartialContent(outputSchema)
@@ -332,35 +486,66 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 				continue
 			}
 			name := block.Labels[0]
-			description := ""
-			isSensitive := false
+
+			o, exists := mod.Outputs[name]
+			if isOverride && !exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing base output definition to override",
+					Detail:   fmt.Sprintf("There is no output named %q. An override file can only override an output that was already defined in a primary configuration file.", name),
+					Subject:  &block.DefRange,
+				})
+				continue
+			}
+			if !isOverride {
+				o = &module.Output{
+					Value:        cty.NilVal,
+					Type:         cty.DynamicPseudoType,
+					DefRangePtr:  block.DefRange.Ptr(),
+					NameRangePtr: block.LabelRanges[0].Ptr(),
+				}
+				mod.Outputs[name] = o
+			}
+
 			var valDiags hcl.Diagnostics
 			if attr, defined := content.Attributes["description"]; defined {
-				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &description)
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &o.Description)
 				diags = append(diags, valDiags...)
 			}
 			if attr, defined := content.Attributes["sensitive"]; defined {
-				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &isSensitive)
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &o.IsSensitive)
 				diags = append(diags, valDiags...)
 			}
-			value := cty.NilVal
 			if attr, defined := content.Attributes["value"]; defined {
-				// TODO: Provide context w/ funcs and variables
-				val, diags := attr.Expr.Value(nil)
-				if !diags.HasErrors() {
-					value = val
-				}
+				// The value is evaluated once all variables and locals are known
+				mod.outputValueExprs[name] = attr.Expr
 			}
-			deprecated := ""
 			if attr, defined := content.Attributes["deprecated"]; defined {
-				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &deprecated)
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &o.Deprecated)
+				diags = append(diags, valDiags...)
+			}
+			if attr, defined := content.Attributes["ephemeral"]; defined {
+				valDiags = gohcl.DecodeExpression(attr.Expr, nil, &o.IsEphemeral)
 				diags = append(diags, valDiags...)
 			}
-			mod.Outputs[name] = &module.Output{
-				Description: description,
-				IsSensitive: isSensitive,
-				Value:       value,
-				Deprecated:  deprecated,
+			if attr, defined := content.Attributes["depends_on"]; defined {
+				dependsOn, dDiags := decodeDependsOn(attr)
+				diags = append(diags, dDiags...)
+				o.DependsOn = dependsOn
+			}
+
+			var preconditions []module.CheckRule
+			for _, innerBlock := range content.Blocks {
+				if innerBlock.Type != "precondition" {
+					continue
+				}
+				precondition, pDiags := decodeCheckRuleBlock(innerBlock)
+				diags = append(diags, pDiags...)
+				preconditions = append(preconditions, precondition)
+			}
+			// Preconditions from an override file replace the original ones
+			if len(preconditions) > 0 || !isOverride {
+				o.Preconditions = preconditions
 			}
 		case "module":
 			content, remainingBody, contentDiags := block.Body.PartialContent(moduleSchema)
@@ -369,9 +554,33 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 				continue
 			}
 			name := block.Labels[0]
-			source := ""
-			var sourceExpr hcl.Expression
-			var versionCons version.Constraints
+
+			mc, exists := mod.ModuleCalls[name]
+			if isOverride && !exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing module call to override",
+					Detail:   fmt.Sprintf("There is no module call named %q. An override file can only override a module call that was defined in a primary configuration file.", name),
+					Subject:  &block.DefRange,
+				})
+				continue
+			}
+			if !isOverride {
+				var rng *hcl.Range
+				hclBody, ok := block.Body.(*hclsyntax.Body)
+				if ok {
+					rng = hclBody.Range().Ptr()
+				}
+
+				mc = &module.DeclaredModuleCall{
+					LocalName:    name,
+					InputNames:   make([]string, 0),
+					RangePtr:     rng,
+					DefRangePtr:  block.DefRange.Ptr(),
+					NameRangePtr: block.LabelRanges[0].Ptr(),
+				}
+				mod.ModuleCalls[name] = mc
+			}
 
 			if attr, defined := content.Attributes["source"]; defined {
 				// If the source is defined then we should attempt to decode this now, or later
@@ -382,57 +591,112 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 					// The source references variables or locals; keep the
 					// expression so it can be resolved in a second pass and
 					// matched against its dependent schema.
-					sourceExpr = attr.Expr
+					mc.RawSourceAddr = ""
+					mc.SourceAddr = nil
+					mc.SourceAddrExpr = attr.Expr
 					mod.moduleSourceExprs[name] = attr.Expr
 				} else {
-					source = s
+					mc.RawSourceAddr = s
+					mc.SourceAddr = module.ParseModuleSourceAddr(s)
+					mc.SourceAddrExpr = nil
+					delete(mod.moduleSourceExprs, name)
 				}
 			}
 			if attr, defined := content.Attributes["version"]; defined {
 				// similar to the source attribute, let's handle the expression later if we need to.
+				mc.Version = nil
 				var versionStr string
 				vDiags := gohcl.DecodeExpression(attr.Expr, nil, &versionStr)
 				if vDiags.HasErrors() {
 					mod.moduleVersionExprs[name] = attr.Expr
 				} else {
+					delete(mod.moduleVersionExprs, name)
 					if versionStr != "" {
 						if vc, err := version.NewConstraint(versionStr); err == nil {
-							versionCons = vc
+							mc.Version = vc
 						}
 					}
 				}
 			}
 
-			inputNames := make([]string, 0)
-			remainingAttributes, diags := remainingBody.JustAttributes()
-			if !diags.HasErrors() {
-				for name := range remainingAttributes {
-					inputNames = append(inputNames, name)
+			if attr, defined := content.Attributes["providers"]; defined {
+				providers, pDiags := decodeModuleProviders(attr)
+				diags = append(diags, pDiags...)
+				mc.Providers = providers
+			}
+			mergeRepetitionArguments(content, &mc.HasCount, &mc.HasForEach)
+			if attr, defined := content.Attributes["depends_on"]; defined {
+				dependsOn, dDiags := decodeDependsOn(attr)
+				diags = append(diags, dDiags...)
+				mc.DependsOn = dependsOn
+			}
+
+			// Attributes are returned even if the body also contains
+			// unexpected blocks, so that valid inputs aren't reported
+			// as missing when validating the call
+			remainingAttributes, attrDiags := remainingBody.JustAttributes()
+			diags = append(diags, attrDiags...)
+			for name, attr := range remainingAttributes {
+				if !slices.Contains(mc.InputNames, name) {
+					mc.InputNames = append(mc.InputNames, name)
+				}
+				if mc.Inputs == nil {
+					mc.Inputs = make(map[string]module.ModuleCallInput)
+				}
+				mc.Inputs[name] = module.ModuleCallInput{
+					Expr:          attr.Expr,
+					NameRangePtr:  attr.NameRange.Ptr(),
+					ValueRangePtr: attr.Expr.Range().Ptr(),
 				}
 			}
 
-			sort.Strings(inputNames)
+			sort.Strings(mc.InputNames)
 
-			var rng *hcl.Range
-			hclBody, ok := block.Body.(*hclsyntax.Body)
-			if ok {
-				rng = hclBody.Range().Ptr()
+		case "moved", "import", "removed":
+			if isOverride {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("Cannot override '%s' blocks", block.Type),
+					Detail:   fmt.Sprintf("The %s blocks can appear only in normal files, not in override files.", block.Type),
+					Subject:  &block.DefRange,
+				})
+				continue
 			}
 
-			mod.ModuleCalls[name] = &module.DeclaredModuleCall{
-				LocalName:      name,
-				RawSourceAddr:  source,
-				SourceAddr:     module.ParseModuleSourceAddr(source),
-				Version:        versionCons,
-				InputNames:     inputNames,
-				RangePtr:       rng,
-				SourceAddrExpr: sourceExpr,
+			switch block.Type {
+			case "moved":
+				moved, mDiags := decodeMovedBlock(block)
+				diags = append(diags, mDiags...)
+				if moved != nil {
+					mod.Moved = append(mod.Moved, *moved)
+				}
+			case "import":
+				imp, iDiags := decodeImportBlock(block, file.Bytes)
+				diags = append(diags, iDiags...)
+				if imp != nil {
+					mod.Imports = append(mod.Imports, *imp)
+				}
+			case "removed":
+				removed, rDiags := decodeRemovedBlock(block)
+				diags = append(diags, rDiags...)
+				if removed != nil {
+					mod.Removed = append(mod.Removed, *removed)
+				}
 			}
 
 		case "locals":
 			// We need the local expressions here to evaluate later
 			attrs, _ := block.Body.JustAttributes()
 			for localName, attr := range attrs {
+				if _, exists := mod.localExprs[localName]; isOverride && !exists {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Missing base local value definition to override",
+						Detail:   fmt.Sprintf("There is no local value named %q. An override file can only override a local value defined in a primary configuration file.", localName),
+						Subject:  &attr.NameRange,
+					})
+					continue
+				}
 				mod.localExprs[localName] = attr.Expr
 			}
 		}
@@ -441,6 +705,37 @@ func loadModuleFromFile(file *hcl.File, mod *decodedModule) hcl.Diagnostics {
 	return diags
 }
 
+// convertVariableDefault converts the given default value to the
+// variable's type constraint
+func convertVariableDefault(val cty.Value, varType cty.Type, rng hcl.Range) (cty.Value, hcl.Diagnostics) {
+	if varType == cty.NilType {
+		return val, nil
+	}
+
+	val, err := convert.Convert(val, varType)
+	if err != nil {
+		return cty.DynamicVal, hcl.Diagnostics{
+			&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid default value for variable",
+				Detail:   fmt.Sprintf("This default value is not compatible with the variable's type constraint: %s.", err),
+				Subject:  rng.Ptr(),
+			},
+		}
+	}
+	return val, nil
+}
+
+// removeFirstString removes the first occurrence of the given value
+// from the given slice
+func removeFirstString(values []string, value string) []string {
+	idx := slices.Index(values, value)
+	if idx == -1 {
+		return values
+	}
+	return slices.Delete(values, idx, idx+1)
+}
+
 func decodeProviderAttribute(attr *hcl.Attribute) (module.ProviderRef, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
@@ -488,3 +783,125 @@ func decodeProviderAttribute(attr *hcl.Attribute) (module.ProviderRef, hcl.Diagn
 		},
 	}
 }
+
+// decodeModuleProviders decodes the providers argument of a module call,
+// which maps provider configurations of the child module to those
+// of the calling module
+func decodeModuleProviders(attr *hcl.Attribute) (map[module.ProviderRef]module.ProviderRef, hcl.Diagnostics) {
+	pairs, diags := hcl.ExprMap(attr.Expr)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	providers := make(map[module.ProviderRef]module.ProviderRef, len(pairs))
+	for _, pair := range pairs {
+		childTraversal, travDiags := hcl.AbsTraversalForExpr(pair.Key)
+		if travDiags.HasErrors() {
+			diags = append(diags, travDiags...)
+			continue
+		}
+		childRef, refDiags := parseProviderRef(childTraversal)
+		diags = append(diags, refDiags...)
+		if refDiags.HasErrors() {
+			continue
+		}
+
+		parentTraversal, travDiags := hcl.AbsTraversalForExpr(pair.Value)
+		if travDiags.HasErrors() {
+			diags = append(diags, travDiags...)
+			continue
+		}
+		parentRef, refDiags := parseProviderRef(parentTraversal)
+		diags = append(diags, refDiags...)
+		if refDiags.HasErrors() {
+			continue
+		}
+
+		if _, exists := providers[childRef]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate provider address",
+				Detail:   "The same provider configuration of the module is assigned more than once in the providers argument.",
+				Subject:  pair.Key.Range().Ptr(),
+			})
+			continue
+		}
+		providers[childRef] = parentRef
+	}
+
+	return providers, diags
+}
+
+// decodeCheckRuleBlock decodes a custom condition block,
+// such as validation or precondition
+func decodeCheckRuleBlock(block *hcl.Block) (module.CheckRule, hcl.Diagnostics) {
+	content, _, diags := block.Body.PartialContent(checkRuleSchema)
+
+	rule := module.CheckRule{
+		DefRangePtr: block.DefRange.Ptr(),
+	}
+	if attr, defined := content.Attributes["condition"]; defined {
+		rule.Condition = attr.Expr
+	}
+	if attr, defined := content.Attributes["error_message"]; defined {
+		rule.ErrorMessage = attr.Expr
+	}
+
+	return rule, diags
+}
+
+// decodeDependsOn decodes references from a depends_on argument
+func decodeDependsOn(attr *hcl.Attribute) ([]string, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	exprs, listDiags := hcl.ExprList(attr.Expr)
+	if listDiags.HasErrors() {
+		return nil, listDiags
+	}
+
+	dependsOn := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		traversal, travDiags := hcl.AbsTraversalForExpr(expr)
+		if travDiags.HasErrors() {
+			diags = append(diags, travDiags...)
+			continue
+		}
+		ref, ok := traversalString(traversal)
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid depends_on reference",
+				Detail:   "References in depends_on must be to a whole object or to a static instance.",
+				Subject:  expr.Range().Ptr(),
+			})
+			continue
+		}
+		dependsOn = append(dependsOn, ref)
+	}
+
+	return dependsOn, diags
+}
+
+// traversalString formats a static traversal as a reference string,
+// e.g. module.foo["a"] or aws_instance.bar[0]
+func traversalString(traversal hcl.Traversal) (string, bool) {
+	var sb strings.Builder
+	for _, step := range traversal {
+		switch ts := step.(type) {
+		case hcl.TraverseRoot:
+			sb.WriteString(ts.Name)
+		case hcl.TraverseAttr:
+			sb.WriteString(".")
+			sb.WriteString(ts.Name)
+		case hcl.TraverseIndex:
+			key, err := addrs.ParseInstanceKey(ts.Key)
+			if err != nil {
+				return "", false
+			}
+			sb.WriteString(key.String())
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
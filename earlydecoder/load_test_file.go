@@ -0,0 +1,152 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+// LoadTest decodes a single test file (*.tftest.hcl or *.tofutest.hcl)
+// found in the given path and returns the runs declared in it along with
+// the provider configurations it overrides or mocks.
+func LoadTest(path string, filename string, file *hcl.File) (*module.TestMeta, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	meta := &module.TestMeta{
+		Path:          path,
+		Filename:      filename,
+		Runs:          make([]module.TestRun, 0),
+		ProviderRefs:  make([]module.ProviderRef, 0),
+		MockProviders: make([]module.ProviderRef, 0),
+	}
+
+	content, _, contentDiags := file.Body.PartialContent(testRootSchema)
+	diags = append(diags, contentDiags...)
+
+	runNames := make(map[string]hcl.Range)
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "run":
+			if len(block.Labels) != 1 || block.Labels[0] == "" {
+				continue
+			}
+			name := block.Labels[0]
+			if prevRng, exists := runNames[name]; exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate run block",
+					Detail:   fmt.Sprintf("A run block named %q was already declared at %s. Run names must be unique within a test file.", name, prevRng),
+					Subject:  &block.DefRange,
+				})
+				continue
+			}
+			runNames[name] = block.DefRange
+
+			run, runDiags := decodeTestRunBlock(block)
+			diags = append(diags, runDiags...)
+			meta.Runs = append(meta.Runs, run)
+
+		case "provider", "mock_provider":
+			content, _, contentDiags := block.Body.PartialContent(testProviderSchema)
+			diags = append(diags, contentDiags...)
+
+			ref := module.ProviderRef{
+				LocalName: block.Labels[0],
+			}
+			if attr, defined := content.Attributes["alias"]; defined {
+				valDiags := gohcl.DecodeExpression(attr.Expr, nil, &ref.Alias)
+				diags = append(diags, valDiags...)
+			}
+
+			if block.Type == "mock_provider" {
+				meta.MockProviders = append(meta.MockProviders, ref)
+			} else {
+				meta.ProviderRefs = append(meta.ProviderRefs, ref)
+			}
+		}
+	}
+
+	return meta, diags
+}
+
+func decodeTestRunBlock(block *hcl.Block) (module.TestRun, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	run := module.TestRun{
+		Name:    block.Labels[0],
+		Command: module.TestCommandApply,
+	}
+
+	if hclBody, ok := block.Body.(*hclsyntax.Body); ok {
+		run.RangePtr = hclBody.Range().Ptr()
+	}
+
+	content, _, contentDiags := block.Body.PartialContent(testRunSchema)
+	diags = append(diags, contentDiags...)
+
+	if attr, defined := content.Attributes["command"]; defined {
+		// command is a keyword, i.e. a naked traversal
+		switch hcl.ExprAsKeyword(attr.Expr) {
+		case "apply":
+			run.Command = module.TestCommandApply
+		case "plan":
+			run.Command = module.TestCommandPlan
+		default:
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid run command",
+				Detail:   "Invalid value for run command, expected \"apply\" or \"plan\".",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	for _, innerBlock := range content.Blocks {
+		if innerBlock.Type != "module" {
+			continue
+		}
+
+		mContent, _, mDiags := innerBlock.Body.PartialContent(moduleSchema)
+		diags = append(diags, mDiags...)
+
+		if attr, defined := mContent.Attributes["source"]; defined {
+			var source string
+			valDiags := gohcl.DecodeExpression(attr.Expr, nil, &source)
+			diags = append(diags, valDiags...)
+			if !valDiags.HasErrors() {
+				run.RawModuleSource = source
+				run.ModuleSource = module.ParseModuleSourceAddr(source)
+			}
+		}
+		if attr, defined := mContent.Attributes["version"]; defined {
+			var versionStr string
+			valDiags := gohcl.DecodeExpression(attr.Expr, nil, &versionStr)
+			diags = append(diags, valDiags...)
+			if !valDiags.HasErrors() && versionStr != "" {
+				vc, err := version.NewConstraint(versionStr)
+				if err != nil {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid version constraint",
+						Detail:   fmt.Sprintf("Constraint %q is not a valid constraint: %s", versionStr, err),
+						Subject:  attr.Expr.Range().Ptr(),
+					})
+				} else {
+					run.ModuleVersion = vc
+				}
+			}
+		}
+	}
+
+	return run, diags
+}
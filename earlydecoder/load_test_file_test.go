@@ -0,0 +1,138 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+func TestLoadTest(t *testing.T) {
+	path := t.TempDir()
+
+	cfg := `
+variables {
+  env = "test"
+}
+
+provider "aws" {
+  region = "eu-west-1"
+}
+
+mock_provider "google" {
+  alias = "fake"
+}
+
+run "setup" {
+  module {
+    source  = "./testing/setup"
+  }
+}
+
+run "plan_only" {
+  command = plan
+
+  assert {
+    condition     = aws_s3_bucket.b.bucket == "test"
+    error_message = "invalid bucket name"
+  }
+}
+
+run "registry" {
+  command = apply
+
+  module {
+    source  = "terraform-aws-modules/vpc/aws"
+    version = "~> 5.0"
+  }
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tftest.hcl", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	meta, diags := LoadTest(path, "main.tftest.hcl", f)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	expectedMeta := &module.TestMeta{
+		Path:     path,
+		Filename: "main.tftest.hcl",
+		Runs: []module.TestRun{
+			{
+				Name:            "setup",
+				Command:         module.TestCommandApply,
+				RawModuleSource: "./testing/setup",
+				ModuleSource:    module.LocalSourceAddr("./testing/setup"),
+			},
+			{
+				Name:    "plan_only",
+				Command: module.TestCommandPlan,
+			},
+			{
+				Name:            "registry",
+				Command:         module.TestCommandApply,
+				RawModuleSource: "terraform-aws-modules/vpc/aws",
+				ModuleSource:    module.ParseModuleSourceAddr("terraform-aws-modules/vpc/aws"),
+				ModuleVersion:   version.MustConstraints(version.NewConstraint("~> 5.0")),
+			},
+		},
+		ProviderRefs: []module.ProviderRef{
+			{LocalName: "aws"},
+		},
+		MockProviders: []module.ProviderRef{
+			{LocalName: "google", Alias: "fake"},
+		},
+	}
+
+	opts := append(customComparer, cmpopts.IgnoreFields(module.TestRun{}, "RangePtr"))
+	if diff := cmp.Diff(expectedMeta, meta, opts...); diff != "" {
+		t.Fatalf("test meta doesn't match: %s", diff)
+	}
+
+	for _, run := range meta.Runs {
+		if run.RangePtr == nil {
+			t.Fatalf("expected range for run %q", run.Name)
+		}
+	}
+}
+
+func TestLoadTest_invalid(t *testing.T) {
+	cfg := `
+run "first" {
+  command = destroy
+}
+
+run "first" {
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tofutest.hcl", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	meta, diags := LoadTest(t.TempDir(), "main.tofutest.hcl", f)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %s", len(diags), diags)
+	}
+	if diags[0].Summary != "Invalid run command" {
+		t.Fatalf("unexpected diagnostic: %s", diags[0].Summary)
+	}
+	if diags[1].Summary != "Duplicate run block" {
+		t.Fatalf("unexpected diagnostic: %s", diags[1].Summary)
+	}
+	if len(meta.Runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(meta.Runs))
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/opentofu/opentofu-schema/module"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+// LoadLockFile decodes the dependency lock file (.terraform.lock.hcl)
+// written by tofu init
+func LoadLockFile(file *hcl.File) (module.ProviderLocks, hcl.Diagnostics) {
+	locks := make(module.ProviderLocks)
+
+	content, diags := file.Body.Content(lockFileSchema)
+	for _, block := range content.Blocks {
+		lock, lDiags := decodeProviderLockBlock(block)
+		diags = append(diags, lDiags...)
+		if lock == nil {
+			continue
+		}
+
+		if existing, exists := locks[lock.Address]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate provider lock",
+				Detail:   fmt.Sprintf("This lock file already declared a lock for provider %s at %s.", lock.Address.ForDisplay(), existing.DefRangePtr),
+				Subject:  lock.DefRangePtr,
+			})
+			continue
+		}
+		locks[lock.Address] = *lock
+	}
+
+	return locks, diags
+}
+
+func decodeProviderLockBlock(block *hcl.Block) (*module.ProviderLock, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	addr, err := tfaddr.ParseProviderSource(block.Labels[0])
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider source address",
+				Detail:   fmt.Sprintf("Cannot lock a version for provider %q: %s.", block.Labels[0], err),
+				Subject:  block.LabelRanges[0].Ptr(),
+			},
+		}
+	}
+
+	content, contentDiags := block.Body.Content(providerLockSchema)
+	diags = append(diags, contentDiags...)
+
+	lock := &module.ProviderLock{
+		Address:     addr,
+		DefRangePtr: block.DefRange.Ptr(),
+	}
+
+	if attr, defined := content.Attributes["version"]; defined {
+		lock.VersionRangePtr = attr.Expr.Range().Ptr()
+
+		var rawVersion string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &rawVersion)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			v, err := version.NewVersion(rawVersion)
+			if err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid provider version number",
+					Detail:   fmt.Sprintf("The selected version number for provider %s is invalid: %s.", addr.ForDisplay(), err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+			lock.Version = v
+		}
+	}
+
+	if attr, defined := content.Attributes["constraints"]; defined {
+		var rawConstraints string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &rawConstraints)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			c, err := version.NewConstraint(rawConstraints)
+			if err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid provider version constraints",
+					Detail:   fmt.Sprintf("The recorded version constraints for provider %s are invalid: %s.", addr.ForDisplay(), err),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+			lock.Constraints = c
+		}
+	}
+
+	if attr, defined := content.Attributes["hashes"]; defined {
+		var hashes []string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &hashes)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && len(hashes) > 0 {
+			lock.Hashes = hashes
+		}
+	}
+
+	return lock, diags
+}
+
+// CheckProviderLocks reports providers required by the given module
+// whose locked version is no longer allowed by the version constraints,
+// which means tofu init -upgrade has to be run.
+//
+// Providers which are not locked are not reported, as they may
+// not have been installed yet.
+func CheckProviderLocks(meta *module.Meta, locks module.ProviderLocks) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	addrs := make([]tfaddr.Provider, 0, len(meta.ProviderRequirements))
+	for addr := range meta.ProviderRequirements {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].String() < addrs[j].String()
+	})
+
+	for _, addr := range addrs {
+		constraints := meta.ProviderRequirements[addr]
+		lock, ok := locks[addr]
+		if !ok || lock.Version == nil || len(constraints) == 0 {
+			continue
+		}
+		if constraints.Check(lock.Version) {
+			continue
+		}
+
+		subject := lock.VersionRangePtr
+		for _, req := range meta.DeclaredProviderRequirements {
+			if req.Source.Equals(addr) {
+				subject = req.DefRangePtr
+				break
+			}
+		}
+
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Inconsistent dependency lock file",
+			Detail: fmt.Sprintf("The locked version %s of provider %s does not match the configured version constraints %q. Run \"tofu init -upgrade\" to select a matching version.",
+				lock.Version, addr.ForDisplay(), constraints.String()),
+			Subject: subject,
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,181 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/module"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+const testLockFile = `# This file is maintained automatically by "tofu init".
+# Manual edits may be lost in future updates.
+
+provider "registry.opentofu.org/hashicorp/aws" {
+  version     = "5.31.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+    "zh:0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d",
+  ]
+}
+
+provider "registry.opentofu.org/hashicorp/random" {
+  version = "3.6.0"
+}
+`
+
+func TestLoadLockFile(t *testing.T) {
+	f, diags := hclsyntax.ParseConfig([]byte(testLockFile), module.LockFileName, hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	locks, diags := LoadLockFile(f)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	awsAddr := tfaddr.MustParseProviderSource("registry.opentofu.org/hashicorp/aws")
+	randomAddr := tfaddr.MustParseProviderSource("registry.opentofu.org/hashicorp/random")
+	expectedLocks := module.ProviderLocks{
+		awsAddr: {
+			Address:     awsAddr,
+			Version:     version.Must(version.NewVersion("5.31.0")),
+			Constraints: version.MustConstraints(version.NewConstraint("~> 5.0")),
+			Hashes: []string{
+				"h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+				"zh:0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d",
+			},
+			DefRangePtr: &hcl.Range{
+				Filename: module.LockFileName,
+				Start:    hcl.Pos{Line: 4, Column: 1, Byte: 103},
+				End:      hcl.Pos{Line: 4, Column: 47, Byte: 149},
+			},
+			VersionRangePtr: &hcl.Range{
+				Filename: module.LockFileName,
+				Start:    hcl.Pos{Line: 5, Column: 17, Byte: 168},
+				End:      hcl.Pos{Line: 5, Column: 25, Byte: 176},
+			},
+		},
+		randomAddr: {
+			Address: randomAddr,
+			Version: version.Must(version.NewVersion("3.6.0")),
+			DefRangePtr: &hcl.Range{
+				Filename: module.LockFileName,
+				Start:    hcl.Pos{Line: 13, Column: 1, Byte: 352},
+				End:      hcl.Pos{Line: 13, Column: 50, Byte: 401},
+			},
+			VersionRangePtr: &hcl.Range{
+				Filename: module.LockFileName,
+				Start:    hcl.Pos{Line: 14, Column: 13, Byte: 416},
+				End:      hcl.Pos{Line: 14, Column: 20, Byte: 423},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedLocks, locks, customComparer...); diff != "" {
+		t.Fatalf("locks mismatch: %s", diff)
+	}
+}
+
+func TestLoadLockFile_invalid(t *testing.T) {
+	cfg := `
+provider "registry.opentofu.org/hashicorp/aws" {
+  version = "foo"
+}
+
+provider "registry.opentofu.org/hashicorp/aws" {
+  version = "5.0.0"
+}
+
+provider "not a valid address!" {
+  version = "1.0.0"
+}
+
+provider "registry.opentofu.org/hashicorp/random" {
+  constraints = "~> 3.0"
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), module.LockFileName, hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	_, diags = LoadLockFile(f)
+	summaries := make([]string, 0)
+	for _, diag := range diags {
+		summaries = append(summaries, diag.Summary)
+	}
+	expectedSummaries := []string{
+		"Invalid provider version number",
+		"Duplicate provider lock",
+		"Invalid provider source address",
+		"Missing required argument",
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("diagnostics mismatch: %s", diff)
+	}
+}
+
+func TestCheckProviderLocks(t *testing.T) {
+	cfg := `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 6.0"
+    }
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.5"
+    }
+    null = {
+      source = "hashicorp/null"
+    }
+  }
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	meta, diags := LoadModule(t.TempDir(), map[string]*hcl.File{"main.tf": f})
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	lf, diags := hclsyntax.ParseConfig([]byte(testLockFile), module.LockFileName, hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+	locks, diags := LoadLockFile(lf)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	diags = CheckProviderLocks(meta, locks)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+	}
+
+	expectedDetail := `The locked version 5.31.0 of provider hashicorp/aws does not match the configured version constraints ">= 6.0". Run "tofu init -upgrade" to select a matching version.`
+	if diags[0].Detail != expectedDetail {
+		t.Fatalf("unexpected detail: %q", diags[0].Detail)
+	}
+	expectedSubject := &hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 4, Column: 5, Byte: 40},
+		End:      hcl.Pos{Line: 7, Column: 6, Byte: 110},
+	}
+	if diff := cmp.Diff(expectedSubject, diags[0].Subject); diff != "" {
+		t.Fatalf("unexpected subject: %s", diff)
+	}
+}
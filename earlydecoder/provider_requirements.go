@@ -18,6 +18,10 @@ type providerRequirement struct {
 	Source               string
 	VersionConstraints   []string
 	ConfigurationAliases []module.ProviderRef
+
+	// Declarations holds ranges of all required_providers
+	// entries which make up the requirement
+	Declarations []declRange
 }
 
 func decodeRequiredProvidersBlock(block *hcl.Block) (map[string]*providerRequirement, hcl.Diagnostics) {
@@ -32,6 +36,7 @@ func decodeRequiredProvidersBlock(block *hcl.Block) (map[string]*providerRequire
 			if !valDiags.HasErrors() {
 				reqs[name] = &providerRequirement{
 					VersionConstraints: []string{version},
+					Declarations:       []declRange{attributeDeclRange(attr)},
 				}
 			}
 			continue
@@ -48,7 +53,9 @@ func decodeRequiredProvidersBlock(block *hcl.Block) (map[string]*providerRequire
 			continue
 		}
 
-		var pr providerRequirement
+		pr := providerRequirement{
+			Declarations: []declRange{attributeDeclRange(attr)},
+		}
 
 		for _, kv := range kvs {
 			key, keyDiags := kv.Key.Value(nil)
@@ -114,6 +121,13 @@ func decodeRequiredProvidersBlock(block *hcl.Block) (map[string]*providerRequire
 	return reqs, diags
 }
 
+func attributeDeclRange(attr *hcl.Attribute) declRange {
+	return declRange{
+		DefRange:  attr.Range,
+		NameRange: attr.NameRange,
+	}
+}
+
 func decodeConfigurationAliases(localName string, value hcl.Expression) ([]module.ProviderRef, hcl.Diagnostics) {
 	aliases := make([]module.ProviderRef, 0)
 	var diags hcl.Diagnostics
@@ -0,0 +1,215 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/opentofu/opentofu-schema/addrs"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+func decodeMovedBlock(block *hcl.Block) (*module.Moved, hcl.Diagnostics) {
+	content, _, diags := block.Body.PartialContent(movedSchema)
+
+	fromAttr, fromDefined := content.Attributes["from"]
+	toAttr, toDefined := content.Attributes["to"]
+	if !fromDefined || !toDefined {
+		return nil, diags
+	}
+
+	from, fromDiags := decodeAddressAttribute(fromAttr)
+	diags = append(diags, fromDiags...)
+	to, toDiags := decodeAddressAttribute(toAttr)
+	diags = append(diags, toDiags...)
+	if fromDiags.HasErrors() || toDiags.HasErrors() {
+		return nil, diags
+	}
+
+	return &module.Moved{
+		From:         from,
+		To:           to,
+		DefRangePtr:  block.DefRange.Ptr(),
+		FromRangePtr: fromAttr.Expr.Range().Ptr(),
+		ToRangePtr:   toAttr.Expr.Range().Ptr(),
+	}, diags
+}
+
+func decodeImportBlock(block *hcl.Block, src []byte) (*module.Import, hcl.Diagnostics) {
+	content, _, diags := block.Body.PartialContent(importSchema)
+
+	toAttr, toDefined := content.Attributes["to"]
+	if !toDefined {
+		return nil, diags
+	}
+
+	imp := &module.Import{
+		DefRangePtr: block.DefRange.Ptr(),
+		ToRangePtr:  toAttr.Expr.Range().Ptr(),
+	}
+
+	_, imp.HasForEach = content.Attributes["for_each"]
+
+	to, toDiags := decodeAddressAttribute(toAttr)
+	if toDiags.HasErrors() {
+		if !imp.HasForEach {
+			diags = append(diags, toDiags...)
+			return nil, diags
+		}
+		// Addresses of imports with for_each typically contain
+		// references (e.g. to each.key), which we cannot resolve here
+		to = string(toAttr.Expr.Range().SliceBytes(src))
+	}
+	imp.To = to
+
+	if attr, defined := content.Attributes["id"]; defined {
+		imp.IDRangePtr = attr.Expr.Range().Ptr()
+		var id string
+		// The ID may be computed from references, which we don't resolve
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &id)
+		if !valDiags.HasErrors() {
+			imp.ID = id
+		}
+	}
+
+	if attr, defined := content.Attributes["provider"]; defined {
+		ref, aDiags := decodeProviderAttribute(attr)
+		diags = append(diags, aDiags...)
+		imp.Provider = &ref
+	}
+
+	return imp, diags
+}
+
+func decodeRemovedBlock(block *hcl.Block) (*module.Removed, hcl.Diagnostics) {
+	content, _, diags := block.Body.PartialContent(removedSchema)
+
+	fromAttr, fromDefined := content.Attributes["from"]
+	if !fromDefined {
+		return nil, diags
+	}
+
+	from, fromDiags := decodeAddressAttribute(fromAttr)
+	diags = append(diags, fromDiags...)
+	if fromDiags.HasErrors() {
+		return nil, diags
+	}
+
+	removed := &module.Removed{
+		From:         from,
+		DefRangePtr:  block.DefRange.Ptr(),
+		FromRangePtr: fromAttr.Expr.Range().Ptr(),
+	}
+
+	for _, innerBlock := range content.Blocks {
+		if innerBlock.Type != "lifecycle" {
+			continue
+		}
+		lcContent, _, lcDiags := innerBlock.Body.PartialContent(removedLifecycleSchema)
+		diags = append(diags, lcDiags...)
+		if attr, defined := lcContent.Attributes["destroy"]; defined {
+			valDiags := gohcl.DecodeExpression(attr.Expr, nil, &removed.Destroy)
+			diags = append(diags, valDiags...)
+		}
+	}
+
+	return removed, diags
+}
+
+// decodeAddressAttribute decodes a static module or resource address,
+// such as module.foo["a"].aws_instance.bar[0] and returns its canonical
+// string representation
+func decodeAddressAttribute(attr *hcl.Attribute) (string, hcl.Diagnostics) {
+	traversal, diags := hcl.AbsTraversalForExpr(attr.Expr)
+	if diags.HasErrors() {
+		return "", hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid address",
+				Detail:   fmt.Sprintf("The %s argument requires a static module or resource address.", attr.Name),
+				Subject:  attr.Expr.Range().Ptr(),
+			},
+		}
+	}
+
+	target, diags := addrs.ParseTarget(traversal)
+	if diags.HasErrors() {
+		return "", diags
+	}
+
+	return target.Subject.String(), nil
+}
+
+// validateMovedChains reports moved blocks which chain into a cycle,
+// e.g. a -> b, b -> c, c -> a, as such chains have no final address
+func validateMovedChains(moved []module.Moved) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(moved))
+	stack := make([]int, 0)
+
+	var visit func(i int)
+	visit = func(i int) {
+		state[i] = visiting
+		stack = append(stack, i)
+
+		for j, next := range moved {
+			if next.From != moved[i].To {
+				continue
+			}
+			switch state[j] {
+			case unvisited:
+				visit(j)
+			case visiting:
+				// j is on the stack, so everything from j onwards forms a cycle
+				cycleStart := 0
+				for idx, stmtIdx := range stack {
+					if stmtIdx == j {
+						cycleStart = idx
+						break
+					}
+				}
+				diags = append(diags, movedCycleDiagnostic(moved, stack[cycleStart:]))
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[i] = done
+	}
+
+	for i := range moved {
+		if state[i] == unvisited {
+			visit(i)
+		}
+	}
+
+	return diags
+}
+
+func movedCycleDiagnostic(moved []module.Moved, cycle []int) *hcl.Diagnostic {
+	var detail strings.Builder
+	detail.WriteString("The following chained move statements form a cycle, and so there is no final location to move objects to:")
+	for _, i := range cycle {
+		stmt := moved[i]
+		fmt.Fprintf(&detail, "\n  - %s:%d: %s → %s", stmt.DefRangePtr.Filename, stmt.DefRangePtr.Start.Line, stmt.From, stmt.To)
+	}
+	detail.WriteString("\n\nA chain of move statements must end with an address that doesn't appear in any other statements, and which typically also refers to an object still declared in the configuration.")
+
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Cyclic dependency in move statements",
+		Detail:   detail.String(),
+		Subject:  moved[cycle[0]].DefRangePtr,
+	}
+}
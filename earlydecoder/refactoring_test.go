@@ -0,0 +1,232 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package earlydecoder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+var ignoreRefactoringRanges = []cmp.Option{
+	cmpopts.IgnoreFields(module.Moved{}, "DefRangePtr", "FromRangePtr", "ToRangePtr"),
+	cmpopts.IgnoreFields(module.Import{}, "DefRangePtr", "ToRangePtr", "IDRangePtr"),
+	cmpopts.IgnoreFields(module.Removed{}, "DefRangePtr", "FromRangePtr"),
+}
+
+func TestLoadModule_refactoringBlocks(t *testing.T) {
+	cfg := `
+moved {
+  from = aws_instance.old
+  to   = module.web["a"].aws_instance.this[0]
+}
+
+moved {
+  from = module.old
+  to   = module.new
+}
+
+import {
+  to       = aws_instance.imported
+  id       = "i-abcd1234"
+  provider = aws.west
+}
+
+import {
+  for_each = var.ids
+  to       = aws_instance.many[each.key]
+  id       = each.value
+}
+
+removed {
+  from = aws_instance.gone
+}
+
+removed {
+  from = module.legacy
+
+  lifecycle {
+    destroy = true
+  }
+}
+`
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	meta, diags := LoadModule(t.TempDir(), map[string]*hcl.File{"main.tf": f})
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	expectedMoved := []module.Moved{
+		{
+			From: "aws_instance.old",
+			To:   `module.web["a"].aws_instance.this[0]`,
+		},
+		{
+			From: "module.old",
+			To:   "module.new",
+		},
+	}
+	if diff := cmp.Diff(expectedMoved, meta.Moved, ignoreRefactoringRanges...); diff != "" {
+		t.Fatalf("moved mismatch: %s", diff)
+	}
+
+	expectedImports := []module.Import{
+		{
+			To:       "aws_instance.imported",
+			ID:       "i-abcd1234",
+			Provider: &module.ProviderRef{LocalName: "aws", Alias: "west"},
+		},
+		{
+			To:         "aws_instance.many[each.key]",
+			HasForEach: true,
+		},
+	}
+	if diff := cmp.Diff(expectedImports, meta.Imports, ignoreRefactoringRanges...); diff != "" {
+		t.Fatalf("imports mismatch: %s", diff)
+	}
+
+	expectedRemoved := []module.Removed{
+		{
+			From: "aws_instance.gone",
+		},
+		{
+			From:    "module.legacy",
+			Destroy: true,
+		},
+	}
+	if diff := cmp.Diff(expectedRemoved, meta.Removed, ignoreRefactoringRanges...); diff != "" {
+		t.Fatalf("removed mismatch: %s", diff)
+	}
+
+	expectedFromRange := &hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 3, Column: 10, Byte: 18},
+		End:      hcl.Pos{Line: 3, Column: 26, Byte: 34},
+	}
+	if diff := cmp.Diff(expectedFromRange, meta.Moved[0].FromRangePtr); diff != "" {
+		t.Fatalf("moved from range mismatch: %s", diff)
+	}
+}
+
+func TestLoadModule_movedCycles(t *testing.T) {
+	testCases := []struct {
+		name          string
+		cfg           string
+		expectedDiags int
+	}{
+		{
+			"chain without cycle",
+			`
+moved {
+  from = aws_instance.a
+  to   = aws_instance.b
+}
+moved {
+  from = aws_instance.b
+  to   = aws_instance.c
+}
+`,
+			0,
+		},
+		{
+			"direct cycle",
+			`
+moved {
+  from = aws_instance.a
+  to   = aws_instance.b
+}
+moved {
+  from = aws_instance.b
+  to   = aws_instance.a
+}
+`,
+			1,
+		},
+		{
+			"indirect cycle",
+			`
+moved {
+  from = module.a
+  to   = module.b
+}
+moved {
+  from = module.b
+  to   = module.c
+}
+moved {
+  from = module.c
+  to   = module.a
+}
+moved {
+  from = aws_instance.x
+  to   = aws_instance.y
+}
+`,
+			1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, diags := hclsyntax.ParseConfig([]byte(tc.cfg), "main.tf", hcl.InitialPos)
+			if len(diags) > 0 {
+				t.Fatal(diags)
+			}
+
+			_, diags = LoadModule(t.TempDir(), map[string]*hcl.File{"main.tf": f})
+			if len(diags) != tc.expectedDiags {
+				t.Fatalf("expected %d diagnostics, %d given: %s", tc.expectedDiags, len(diags), diags)
+			}
+			for _, diag := range diags {
+				if diag.Summary != "Cyclic dependency in move statements" {
+					t.Fatalf("unexpected diagnostic: %s", diag)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadModule_refactoringBlocksInOverride(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `
+moved {
+  from = aws_instance.a
+  to   = aws_instance.b
+}
+`,
+		"main_override.tf": `
+moved {
+  from = aws_instance.b
+  to   = aws_instance.c
+}
+`,
+	}
+
+	parsedFiles := make(map[string]*hcl.File, len(files))
+	for name, src := range files {
+		f, diags := hclsyntax.ParseConfig([]byte(src), name, hcl.InitialPos)
+		if len(diags) > 0 {
+			t.Fatal(diags)
+		}
+		parsedFiles[name] = f
+	}
+
+	meta, diags := LoadModule(t.TempDir(), parsedFiles)
+	if len(diags) != 1 || diags[0].Summary != "Cannot override 'moved' blocks" {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+	if len(meta.Moved) != 1 {
+		t.Fatalf("expected 1 moved block, %d given", len(meta.Moved))
+	}
+}
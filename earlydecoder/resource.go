@@ -9,13 +9,18 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu-schema/module"
 )
 
 type resource struct {
-	Type     string
-	Name     string
-	Provider module.ProviderRef
+	Type       string
+	Name       string
+	Provider   module.ProviderRef
+	HasCount   bool
+	HasForEach bool
+	DefRange   hcl.Range
+	NameRange  hcl.Range
 }
 
 // MapKey returns a string that can be used to uniquely identify the receiver
@@ -24,6 +29,79 @@ func (r *resource) MapKey() string {
 	return fmt.Sprintf("%s.%s", r.Type, r.Name)
 }
 
+// toModule converts the receiver into the exported representation
+func (r *resource) toModule() module.Resource {
+	return module.Resource{
+		Type:         r.Type,
+		Name:         r.Name,
+		Provider:     r.Provider,
+		HasCount:     r.HasCount,
+		HasForEach:   r.HasForEach,
+		DefRangePtr:  r.DefRange.Ptr(),
+		NameRangePtr: r.NameRange.Ptr(),
+	}
+}
+
+// decodeRepetitionArguments reports whether the count or for_each
+// meta-argument is present in the given resource body content
+func decodeRepetitionArguments(content *hcl.BodyContent) (hasCount, hasForEach bool) {
+	_, hasCount = content.Attributes["count"]
+	_, hasForEach = content.Attributes["for_each"]
+	return hasCount, hasForEach
+}
+
+// mergeRepetitionArguments replaces count and for_each with those
+// set in the given content, e.g. of a block in an override file.
+// They are mutually exclusive, so setting either of them
+// replaces the other one.
+func mergeRepetitionArguments(content *hcl.BodyContent, hasCount, hasForEach *bool) {
+	if c, fe := decodeRepetitionArguments(content); c || fe {
+		*hasCount, *hasForEach = c, fe
+	}
+}
+
+// resourceOverrideTarget refers to the fields of a data source,
+// resource or ephemeral resource which override files can replace
+type resourceOverrideTarget struct {
+	Provider   *module.ProviderRef
+	HasCount   *bool
+	HasForEach *bool
+}
+
+func (r *resource) overrideTarget() *resourceOverrideTarget {
+	return &resourceOverrideTarget{
+		Provider:   &r.Provider,
+		HasCount:   &r.HasCount,
+		HasForEach: &r.HasForEach,
+	}
+}
+
+// mergeResourceOverride merges the given content of an overriding block
+// into its base block. A nil base is reported as missing, using the given
+// summary and detail.
+func mergeResourceOverride(block *hcl.Block, content *hcl.BodyContent, base *resourceOverrideTarget, summary, detail string) hcl.Diagnostics {
+	if base == nil {
+		return hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  summary,
+				Detail:   detail,
+				Subject:  &block.DefRange,
+			},
+		}
+	}
+
+	var diags hcl.Diagnostics
+	if attr, defined := content.Attributes["provider"]; defined {
+		ref, aDiags := decodeProviderAttribute(attr)
+		diags = append(diags, aDiags...)
+		*base.Provider = ref
+	}
+	mergeRepetitionArguments(content, base.HasCount, base.HasForEach)
+
+	return diags
+}
+
 func inferProviderNameFromType(typeName string) string {
 	if underPos := strings.IndexByte(typeName, '_'); underPos != -1 {
 		return typeName[:underPos]
@@ -48,6 +48,15 @@ var rootSchema = &hcl.BodySchema{
 		{
 			Type: "locals",
 		},
+		{
+			Type: "moved",
+		},
+		{
+			Type: "import",
+		},
+		{
+			Type: "removed",
+		},
 	},
 }
 
@@ -105,6 +114,12 @@ var resourceSchema = &hcl.BodySchema{
 		{
 			Name: "provider",
 		},
+		{
+			Name: "count",
+		},
+		{
+			Name: "for_each",
+		},
 	},
 }
 
@@ -125,6 +140,28 @@ var variableSchema = &hcl.BodySchema{
 		{
 			Name: "deprecated",
 		},
+		{
+			Name: "nullable",
+		},
+		{
+			Name: "ephemeral",
+		},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type: "validation",
+		},
+	},
+}
+
+var checkRuleSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "condition",
+		},
+		{
+			Name: "error_message",
+		},
 	},
 }
 
@@ -142,6 +179,17 @@ var outputSchema = &hcl.BodySchema{
 		{
 			Name: "deprecated",
 		},
+		{
+			Name: "ephemeral",
+		},
+		{
+			Name: "depends_on",
+		},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type: "precondition",
+		},
 	},
 }
 
@@ -153,5 +201,90 @@ var moduleSchema = &hcl.BodySchema{
 		{
 			Name: "version",
 		},
+		{
+			Name: "providers",
+		},
+		{
+			Name: "count",
+		},
+		{
+			Name: "for_each",
+		},
+		{
+			Name: "depends_on",
+		},
+	},
+}
+
+var movedSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "from",
+		},
+		{
+			Name: "to",
+		},
+	},
+}
+
+var importSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "to",
+		},
+		{
+			Name: "id",
+		},
+		{
+			Name: "provider",
+		},
+		{
+			Name: "for_each",
+		},
+	},
+}
+
+var removedSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "from",
+		},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type: "lifecycle",
+		},
+	},
+}
+
+var removedLifecycleSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "destroy",
+		},
+	},
+}
+
+var lockFileSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "provider",
+			LabelNames: []string{"source"},
+		},
+	},
+}
+
+var providerLockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "version",
+			Required: true,
+		},
+		{
+			Name: "constraints",
+		},
+		{
+			Name: "hashes",
+		},
 	},
 }
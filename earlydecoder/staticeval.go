@@ -6,40 +6,24 @@
 package earlydecoder
 
 import (
-	"maps"
-
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu-schema/module"
+	"github.com/opentofu/opentofu-schema/staticeval"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
 )
 
 // resolveStaticModuleCalls evaluates the source and version of module calls
 // that reference variables or locals.
-func resolveStaticModuleCalls(mod *decodedModule) {
+func resolveStaticModuleCalls(mod *decodedModule, evalCtx *hcl.EvalContext) {
 	// If we have none of the stored expressions to evaluate in this "second pass"
 	// then we can just exit early
 	if len(mod.moduleSourceExprs) == 0 && len(mod.moduleVersionExprs) == 0 {
 		return
 	}
 
-	vars := make(map[string]cty.Value, len(mod.Variables))
-	for name, v := range mod.Variables {
-		if v.DefaultValue != cty.NilVal {
-			vars[name] = v.DefaultValue
-		}
-	}
-
-	locals := resolveLocals(mod.localExprs, vars)
-
-	evalCtx := &hcl.EvalContext{
-		Variables: map[string]cty.Value{
-			"var":   objectOrEmpty(vars),
-			"local": objectOrEmpty(locals),
-		},
-	}
-
 	for name, expr := range mod.moduleSourceExprs {
 		mc, ok := mod.ModuleCalls[name]
 		if !ok || mc.RawSourceAddr != "" {
@@ -64,40 +48,57 @@ func resolveStaticModuleCalls(mod *decodedModule) {
 	}
 }
 
-func resolveLocals(exprs map[string]hcl.Expression, vars map[string]cty.Value) map[string]cty.Value {
-	resolved := make(map[string]cty.Value, len(exprs))
-	if len(exprs) == 0 {
-		return resolved
+// staticEvalContext returns a context for evaluating expressions referencing
+// variables (via their default values), locals and the given functions.
+// Variables without default value are unknown, with their type (if declared).
+func staticEvalContext(mod *decodedModule, functions map[string]function.Function) *hcl.EvalContext {
+	knownVars := make(map[string]cty.Value, len(mod.Variables))
+	vars := make(map[string]cty.Value, len(mod.Variables))
+	for name, v := range mod.Variables {
+		if v.DefaultValue != cty.NilVal {
+			knownVars[name] = v.DefaultValue
+			vars[name] = v.DefaultValue
+			continue
+		}
+		vars[name] = cty.UnknownVal(v.Type)
 	}
 
-	remaining := make(map[string]hcl.Expression, len(exprs))
-	maps.Copy(remaining, exprs)
+	locals := staticeval.ResolveLocals(mod.localExprs, knownVars, functions)
+	for name := range mod.localExprs {
+		if _, ok := locals[name]; !ok {
+			locals[name] = cty.DynamicVal
+		}
+	}
 
-	for len(remaining) > 0 {
-		ctx := &hcl.EvalContext{
-			Variables: map[string]cty.Value{
-				"var":   objectOrEmpty(vars),
-				"local": objectOrEmpty(resolved),
-			},
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var":   objectOrEmpty(vars),
+			"local": objectOrEmpty(locals),
+		},
+		Functions: functions,
+	}
+}
+
+// resolveOutputs evaluates output values and infers their types.
+// Values which cannot be fully evaluated, e.g. because they refer
+// to resources, are left as cty.NilVal.
+func resolveOutputs(mod *decodedModule, evalCtx *hcl.EvalContext) {
+	for name, expr := range mod.outputValueExprs {
+		o, ok := mod.Outputs[name]
+		if !ok {
+			continue
 		}
 
-		progressed := false
-		for name, expr := range remaining {
-			val, diags := expr.Value(ctx)
-			if diags.HasErrors() || val.IsNull() || !val.IsWhollyKnown() {
-				continue
-			}
-			resolved[name] = val
-			delete(remaining, name)
-			progressed = true
+		val, diags := expr.Value(staticeval.WithPlaceholders(expr, evalCtx))
+		if diags.HasErrors() {
+			continue
 		}
-		// Keep going until we cant progress anymore
-		if !progressed {
-			break
+
+		o.Type = val.Type()
+		if !val.IsNull() && val.IsWhollyKnown() {
+			o.Value = val
 		}
 	}
-
-	return resolved
 }
 
 // evalStaticString evaluates a string, returns false for second return val if it cant resolve
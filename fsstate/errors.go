@@ -0,0 +1,33 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fsstate
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+type RegistryModuleNotFoundErr struct {
+	Addr tfaddr.Module
+}
+
+func (e RegistryModuleNotFoundErr) Error() string {
+	return fmt.Sprintf("registry module %s not found: registry data is not available", e.Addr)
+}
+
+type ProviderSchemaNotFoundErr struct {
+	Addr        tfaddr.Provider
+	Constraints version.Constraints
+}
+
+func (e ProviderSchemaNotFoundErr) Error() string {
+	if len(e.Constraints) > 0 {
+		return fmt.Sprintf("schema of provider %s matching %s not found", e.Addr, e.Constraints)
+	}
+	return fmt.Sprintf("schema of provider %s not found", e.Addr)
+}
@@ -0,0 +1,310 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package fsstate implements schema.StateReader on top of the local
+// filesystem, so that the schema merger can be used without a language server
+package fsstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/opentofu/opentofu-schema/earlydecoder"
+	"github.com/opentofu/opentofu-schema/module"
+	"github.com/opentofu/opentofu-schema/moduletree"
+	"github.com/opentofu/opentofu-schema/registry"
+	"github.com/opentofu/opentofu-schema/schema"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+// ProviderSchemasPath is the path of the output of
+// tofu providers schema -json, relative to a root module
+var ProviderSchemasPath = filepath.Join(".terraform", "providers-schema.json")
+
+var _ schema.StateReader = &Reader{}
+
+// Reader reads module metadata, installed modules and provider schemas
+// from the filesystem and caches them in memory until invalidated.
+// It is safe for concurrent use.
+//
+// Registry data is never fetched, so registry modules are only
+// available when they are installed.
+type Reader struct {
+	fs     moduletree.FS
+	loader *moduletree.Loader
+
+	mu        sync.Mutex
+	modules   map[string]moduleEntry
+	manifests map[string]*module.ModuleManifest
+	// providerSchemas are keyed by the directory in which
+	// the provider schemas were looked up, so that modules
+	// of the same root module share them
+	providerSchemas map[string]*providerSchemasEntry
+}
+
+type moduleEntry struct {
+	meta *module.Meta
+	err  error
+}
+
+type providerSchemasEntry struct {
+	// once guards loading of the entry, which is done without
+	// holding the lock of the reader, since decoding large
+	// provider schemas may take a while
+	once sync.Once
+
+	// found is false if the directory contains no provider schemas
+	found   bool
+	schemas map[tfaddr.Provider]*schema.ProviderSchema
+	locks   module.ProviderLocks
+	err     error
+}
+
+// NewReader returns a reader of the given filesystem,
+// or of the OS filesystem if it is nil
+func NewReader(fsys moduletree.FS) *Reader {
+	loader := moduletree.NewLoader(fsys)
+	if fsys == nil {
+		fsys = osFS{}
+	}
+	return &Reader{
+		fs:              fsys,
+		loader:          loader,
+		modules:         make(map[string]moduleEntry),
+		manifests:       make(map[string]*module.ModuleManifest),
+		providerSchemas: make(map[string]*providerSchemasEntry),
+	}
+}
+
+// DeclaredModuleCalls returns module calls declared in the module at the given path
+func (r *Reader) DeclaredModuleCalls(modPath string) (map[string]module.DeclaredModuleCall, error) {
+	meta, err := r.LocalModuleMeta(modPath)
+	if err != nil {
+		return nil, err
+	}
+	return meta.ModuleCalls, nil
+}
+
+// InstalledModulePath looks up the given normalized source address
+// in the manifest of modules installed for the given root module
+func (r *Reader) InstalledModulePath(rootPath string, normalizedSource string) (string, bool) {
+	manifest := r.manifest(filepath.Clean(rootPath))
+	if manifest == nil {
+		return "", false
+	}
+	return manifest.InstalledModulePath(rootPath, normalizedSource)
+}
+
+// LocalModuleMeta early-decodes the module at the given path.
+//
+// Decoding diagnostics do not cause an error, since the metadata
+// of a module with errors is still useful for completion.
+func (r *Reader) LocalModuleMeta(modPath string) (*module.Meta, error) {
+	modPath = filepath.Clean(modPath)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.modules[modPath]; ok {
+		return entry.meta, entry.err
+	}
+
+	var entry moduleEntry
+	meta, diags := r.loader.LoadModule(modPath)
+	if meta == nil {
+		entry.err = fmt.Errorf("failed to load module %s: %w", modPath, diags)
+	} else {
+		entry.meta = meta
+	}
+	r.modules[modPath] = entry
+
+	return entry.meta, entry.err
+}
+
+// RegistryModuleMeta always returns an error,
+// since registry data is not available on the filesystem
+func (r *Reader) RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*registry.ModuleData, error) {
+	return nil, RegistryModuleNotFoundErr{Addr: addr}
+}
+
+// ProviderSchema returns the schema of the given provider from the nearest
+// cached provider schemas (see ProviderSchemasPath), which are looked up in
+// the module at the given path and then in each of its parent directories.
+//
+// If the directory also contains a dependency lock file, the locked version
+// of the provider must match the given version constraints.
+func (r *Reader) ProviderSchema(modPath string, addr tfaddr.Provider, vc version.Constraints) (*schema.ProviderSchema, error) {
+	entry, err := r.findProviderSchemas(filepath.Clean(modPath))
+	if err != nil {
+		return nil, err
+	}
+	if entry.err != nil {
+		return nil, entry.err
+	}
+
+	ps, ok := entry.schemas[addr]
+	if !ok {
+		return nil, ProviderSchemaNotFoundErr{Addr: addr}
+	}
+
+	if lock, ok := entry.locks[addr]; ok && lock.Version != nil && len(vc) > 0 && !vc.Check(lock.Version) {
+		return nil, ProviderSchemaNotFoundErr{Addr: addr, Constraints: vc}
+	}
+
+	return ps.Copy(), nil
+}
+
+// InvalidateModule drops cached metadata of the module at the given path,
+// e.g. after any of its files changed
+func (r *Reader) InvalidateModule(modPath string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.modules, filepath.Clean(modPath))
+}
+
+// InvalidateInstalledModules drops the cached manifest of modules
+// installed for the given root module, e.g. after tofu init
+func (r *Reader) InvalidateInstalledModules(rootPath string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.manifests, filepath.Clean(rootPath))
+}
+
+// InvalidateProviderSchemas drops all cached provider schemas,
+// e.g. after the provider schemas or lock file changed
+func (r *Reader) InvalidateProviderSchemas() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.providerSchemas)
+}
+
+// InvalidateAll drops all cached data
+func (r *Reader) InvalidateAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.modules)
+	clear(r.manifests)
+	clear(r.providerSchemas)
+}
+
+// manifest returns the manifest of modules installed
+// for the given root module, or nil if there is none
+func (r *Reader) manifest(rootPath string) *module.ModuleManifest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if manifest, ok := r.manifests[rootPath]; ok {
+		return manifest
+	}
+
+	var manifest *module.ModuleManifest
+	b, err := r.fs.ReadFile(filepath.Join(rootPath, module.ManifestPath))
+	if err == nil {
+		manifest, _ = module.ParseModuleManifest(b)
+	}
+	r.manifests[rootPath] = manifest
+
+	return manifest
+}
+
+// findProviderSchemas returns provider schemas of the module at the
+// given path or of the nearest parent directory which contains them
+func (r *Reader) findProviderSchemas(modPath string) (*providerSchemasEntry, error) {
+	dir := modPath
+	for {
+		if entry := r.providerSchemasIn(dir); entry.found {
+			return entry, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	return nil, fmt.Errorf("no provider schemas found for module %s", modPath)
+}
+
+// providerSchemasIn returns the cached provider schemas
+// of the given directory, loading them on first use
+func (r *Reader) providerSchemasIn(dir string) *providerSchemasEntry {
+	r.mu.Lock()
+	entry, ok := r.providerSchemas[dir]
+	if !ok {
+		entry = &providerSchemasEntry{}
+		r.providerSchemas[dir] = entry
+	}
+	r.mu.Unlock()
+
+	entry.once.Do(func() {
+		r.loadProviderSchemas(dir, entry)
+	})
+
+	return entry
+}
+
+func (r *Reader) loadProviderSchemas(dir string, entry *providerSchemasEntry) {
+	b, err := r.fs.ReadFile(filepath.Join(dir, ProviderSchemasPath))
+	if err != nil {
+		return
+	}
+	entry.found = true
+
+	var jsonSchemas tfjson.ProviderSchemas
+	if err := json.Unmarshal(b, &jsonSchemas); err != nil {
+		entry.err = fmt.Errorf("failed to parse provider schemas in %s: %w", dir, err)
+		return
+	}
+
+	schemas := make(map[tfaddr.Provider]*schema.ProviderSchema, len(jsonSchemas.Schemas))
+	for rawAddr, jsonSchema := range jsonSchemas.Schemas {
+		pAddr, err := tfaddr.ParseProviderSource(rawAddr)
+		if err != nil {
+			continue
+		}
+		schemas[pAddr] = schema.ProviderSchemaFromJson(jsonSchema, pAddr)
+	}
+
+	entry.schemas = schemas
+	entry.locks = r.readLockFile(dir)
+}
+
+// readLockFile returns provider locks of the dependency lock file
+// in the given directory, or nil if there is none
+func (r *Reader) readLockFile(dir string) module.ProviderLocks {
+	b, err := r.fs.ReadFile(filepath.Join(dir, module.LockFileName))
+	if err != nil {
+		return nil
+	}
+	f, diags := hclsyntax.ParseConfig(b, module.LockFileName, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+	locks, _ := earlydecoder.LoadLockFile(f)
+	return locks
+}
+
+type osFS struct{}
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
@@ -0,0 +1,195 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package fsstate
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hashicorp/go-version"
+	"github.com/opentofu/opentofu-schema/internal/addr"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+const testProviderSchemas = `{
+  "format_version": "1.0",
+  "provider_schemas": {
+    "registry.opentofu.org/hashicorp/random": {
+      "provider": {"version": 0, "block": {}},
+      "resource_schemas": {
+        "random_id": {
+          "version": 0,
+          "block": {
+            "attributes": {
+              "byte_length": {"type": "number", "required": true}
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"root/main.tf": {Data: []byte(`
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+`)},
+		"root/.terraform/modules/modules.json": {Data: []byte(`{"Modules": [
+  {"Key": "", "Source": "", "Dir": "."},
+  {"Key": "vpc", "Source": "registry.opentofu.org/terraform-aws-modules/vpc/aws", "Version": "5.0.0", "Dir": ".terraform/modules/vpc"}
+]}`)},
+		"root/.terraform/modules/vpc/main.tf": {Data: []byte(`
+variable "cidr" {}
+`)},
+		"root/.terraform/providers-schema.json": {Data: []byte(testProviderSchemas)},
+		"root/.terraform.lock.hcl": {Data: []byte(`
+provider "registry.opentofu.org/hashicorp/random" {
+  version = "3.6.0"
+}
+`)},
+	}
+}
+
+func TestReader_modules(t *testing.T) {
+	fsys := testFS()
+	r := NewReader(fsys)
+
+	calls, err := r.DeclaredModuleCalls("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vpc, ok := calls["vpc"]
+	if !ok {
+		t.Fatal("expected vpc module call")
+	}
+
+	dir, ok := r.InstalledModulePath("root", vpc.SourceAddr.String())
+	if !ok {
+		t.Fatal("expected vpc module to be installed")
+	}
+	expectedDir := filepath.Join(".terraform", "modules", "vpc")
+	if dir != expectedDir {
+		t.Fatalf("expected installed module in %q, given %q", expectedDir, dir)
+	}
+
+	meta, err := r.LocalModuleMeta(filepath.Join("root", dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := meta.Variables["cidr"]; !ok {
+		t.Fatal("expected cidr variable to be decoded")
+	}
+
+	if _, err := r.LocalModuleMeta("missing"); err == nil {
+		t.Fatal("expected error for missing module")
+	}
+
+	_, err = r.RegistryModuleMeta(vpc.SourceAddr.(tfaddr.Module), vpc.Version)
+	var notFoundErr RegistryModuleNotFoundErr
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected RegistryModuleNotFoundErr, given %#v", err)
+	}
+}
+
+func TestReader_ProviderSchema(t *testing.T) {
+	r := NewReader(testFS())
+	random := addr.NewDefaultProvider("random")
+
+	// Provider schemas of the root module are found from child modules
+	modPath := filepath.Join("root", ".terraform", "modules", "vpc")
+	ps, err := r.ProviderSchema(modPath, random, version.MustConstraints(version.NewConstraint("~> 3.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ps.Resources["random_id"]; !ok {
+		t.Fatal("expected random_id resource schema")
+	}
+
+	_, err = r.ProviderSchema("root", random, version.MustConstraints(version.NewConstraint(">= 4.0")))
+	var notFoundErr ProviderSchemaNotFoundErr
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected ProviderSchemaNotFoundErr for locked version, given %#v", err)
+	}
+
+	_, err = r.ProviderSchema("root", addr.NewDefaultProvider("aws"), nil)
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected ProviderSchemaNotFoundErr for unknown provider, given %#v", err)
+	}
+}
+
+func TestReader_ProviderSchema_shared(t *testing.T) {
+	fsys := &countingFS{MapFS: testFS(), reads: make(map[string]int)}
+	r := NewReader(fsys)
+	random := addr.NewDefaultProvider("random")
+
+	// Modules of the same root module share its provider schemas
+	for _, modPath := range []string{"root", filepath.Join("root", ".terraform", "modules", "vpc")} {
+		if _, err := r.ProviderSchema(modPath, random, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	schemasPath := filepath.Join("root", ProviderSchemasPath)
+	if reads := fsys.reads[schemasPath]; reads != 1 {
+		t.Fatalf("expected provider schemas to be read once, read %d times", reads)
+	}
+}
+
+func TestReader_invalidation(t *testing.T) {
+	fsys := testFS()
+	r := NewReader(fsys)
+
+	meta, err := r.LocalModuleMeta("root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.Variables) != 0 {
+		t.Fatalf("expected no variables, given %#v", meta.Variables)
+	}
+
+	fsys["root/variables.tf"] = &fstest.MapFile{Data: []byte(`variable "name" {}`)}
+
+	meta, _ = r.LocalModuleMeta("root")
+	if len(meta.Variables) != 0 {
+		t.Fatal("expected cached module metadata")
+	}
+
+	r.InvalidateModule("root")
+	meta, _ = r.LocalModuleMeta("root")
+	if _, ok := meta.Variables["name"]; !ok {
+		t.Fatal("expected module to be decoded again after invalidation")
+	}
+
+	random := addr.NewDefaultProvider("random")
+	if _, err := r.ProviderSchema("root", random, nil); err != nil {
+		t.Fatal(err)
+	}
+	delete(fsys, "root/.terraform/providers-schema.json")
+	if _, err := r.ProviderSchema("root", random, nil); err != nil {
+		t.Fatal("expected cached provider schema")
+	}
+	r.InvalidateProviderSchemas()
+	if _, err := r.ProviderSchema("root", random, nil); err == nil {
+		t.Fatal("expected error after invalidation of provider schemas")
+	}
+}
+
+// countingFS counts reads of each file
+type countingFS struct {
+	fstest.MapFS
+	reads map[string]int
+}
+
+func (fsys *countingFS) ReadFile(name string) ([]byte, error) {
+	fsys.reads[name]++
+	return fsys.MapFS.ReadFile(name)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package funcs provides signatures of functions available
+// in a particular OpenTofu version
+package funcs
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+
+	funcs_v0_12 "github.com/opentofu/opentofu-schema/internal/funcs/0.12"
+	funcs_v0_13 "github.com/opentofu/opentofu-schema/internal/funcs/0.13"
+	funcs_v0_14 "github.com/opentofu/opentofu-schema/internal/funcs/0.14"
+	funcs_v0_15 "github.com/opentofu/opentofu-schema/internal/funcs/0.15"
+	funcs_v1_3 "github.com/opentofu/opentofu-schema/internal/funcs/1.3"
+	funcs_generated "github.com/opentofu/opentofu-schema/internal/funcs/generated"
+)
+
+var (
+	v0_13 = version.Must(version.NewVersion("0.13"))
+	v0_14 = version.Must(version.NewVersion("0.14"))
+	v0_15 = version.Must(version.NewVersion("0.15"))
+	v1_3  = version.Must(version.NewVersion("1.3"))
+	v1_4  = version.Must(version.NewVersion("1.4"))
+)
+
+// ForVersion returns signatures of functions available in the given version
+func ForVersion(v *version.Version) map[string]schema.FunctionSignature {
+	ver := v.Core()
+	if ver.GreaterThanOrEqual(v1_4) {
+		return funcs_generated.Functions(ver)
+	}
+	if ver.GreaterThanOrEqual(v1_3) {
+		return funcs_v1_3.Functions(ver)
+	}
+	if ver.GreaterThanOrEqual(v0_15) {
+		return funcs_v0_15.Functions(ver)
+	}
+	if ver.GreaterThanOrEqual(v0_14) {
+		return funcs_v0_14.Functions(ver)
+	}
+	if ver.GreaterThanOrEqual(v0_13) {
+		return funcs_v0_13.Functions(ver)
+	}
+
+	// Return the 0.12 functions for any version <= 0.12
+	return funcs_v0_12.Functions(ver)
+}
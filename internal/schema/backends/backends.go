@@ -79,6 +79,25 @@ func ConfigsAsDependentBodies(tfVersion *version.Version) map[schema.SchemaKey]*
 	return depBodies
 }
 
+// ConfigFileBodySchema returns the body schema of the given backend type
+// for use in a partial backend configuration file (*.tfbackend).
+// All attributes are optional there, since the remaining ones may be
+// declared in the backend block itself.
+func ConfigFileBodySchema(backendType string, tfVersion *version.Version) (*schema.BodySchema, bool) {
+	bs, ok := backendBodySchemas(tfVersion)[backendType]
+	if !ok || bs == nil {
+		return nil, false
+	}
+
+	bs = bs.Copy()
+	for _, attr := range bs.Attributes {
+		attr.IsRequired = false
+		attr.IsOptional = true
+	}
+
+	return bs, true
+}
+
 func labelKey(value string) schema.SchemaKey {
 	return schema.NewSchemaKey(schema.DependencyKeys{
 		Labels: []schema.LabelDependent{{Index: 0, Value: value}},
@@ -0,0 +1,59 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/schema/tokmod"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// LockFileSchema returns the schema of the dependency lock file
+// (.terraform.lock.hcl) as introduced in v0.14.
+func LockFileSchema(_ *version.Version) *schema.BodySchema {
+	return &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"provider": providerLockBlockSchema(),
+		},
+	}
+}
+
+func providerLockBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Provider},
+		Labels: []*schema.LabelSchema{
+			{
+				Name:                   "source",
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Name},
+				Description:            lang.PlainText("Fully qualified source address of the provider, e.g. registry.opentofu.org/hashicorp/aws"),
+			},
+		},
+		Description: lang.Markdown("Version and checksums of the provider selected by `tofu init`"),
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"version": {
+					Constraint:  schema.LiteralType{Type: cty.String},
+					IsRequired:  true,
+					Description: lang.PlainText("The selected version of the provider"),
+				},
+				"constraints": {
+					Constraint:  schema.LiteralType{Type: cty.String},
+					IsOptional:  true,
+					Description: lang.Markdown("Version constraints which were used to select the version, e.g. `~> 5.0`"),
+				},
+				"hashes": {
+					Constraint: schema.Set{
+						Elem: schema.LiteralType{Type: cty.String},
+					},
+					IsOptional:  true,
+					Description: lang.PlainText("Checksums of the provider packages which are considered trusted"),
+				},
+			},
+		},
+	}
+}
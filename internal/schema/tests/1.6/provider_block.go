@@ -0,0 +1,51 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/schema/refscope"
+	"github.com/opentofu/opentofu-schema/internal/schema/tokmod"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func providerBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Address: &schema.BlockAddrSchema{
+			Steps: []schema.AddrStep{
+				schema.LabelStep{Index: 0},
+				schema.AttrValueStep{Name: "alias", IsOptional: true},
+			},
+			FriendlyName: "provider",
+			ScopeId:      refscope.ProviderScope,
+			AsReference:  true,
+		},
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Provider},
+		Labels: []*schema.LabelSchema{
+			{
+				Name:                   "name",
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Name, lang.TokenModifierDependent},
+				Description:            lang.PlainText("Provider Name"),
+				IsDepKey:               true,
+				Completable:            true,
+			},
+		},
+		Description: lang.PlainText("A provider block overrides the provider configuration used by runs in this test file"),
+		Body: &schema.BodySchema{
+			Extensions: &schema.BodyExtensions{
+				DynamicBlocks: true,
+			},
+			Attributes: map[string]*schema.AttributeSchema{
+				"alias": {
+					Constraint:  schema.LiteralType{Type: cty.String},
+					IsOptional:  true,
+					Description: lang.Markdown("Alias for using the same provider with different configurations, e.g. `eu-west`"),
+				},
+			},
+		},
+	}
+}
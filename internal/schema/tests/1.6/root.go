@@ -0,0 +1,23 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+)
+
+// TestSchema returns the schema of a test file (*.tftest.hcl or
+// *.tofutest.hcl) as introduced with `tofu test` in OpenTofu v1.6.
+func TestSchema(_ *version.Version) *schema.BodySchema {
+	return &schema.BodySchema{
+		Blocks: map[string]*schema.BlockSchema{
+			"run":       runBlockSchema(),
+			"variables": variablesBlockSchema(),
+			"provider":  providerBlockSchema(),
+		},
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/schema/refscope"
+	"github.com/opentofu/opentofu-schema/internal/schema/tokmod"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func runBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{
+				Name:                   "name",
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Name},
+				Description:            lang.PlainText("Run Name"),
+			},
+		},
+		Description: lang.Markdown("A `run` block executes a single OpenTofu command (`apply` or `plan`) " +
+			"against the configuration under test and validates the result using `assert` blocks"),
+		Body: &schema.BodySchema{
+			HoverURL: "https://opentofu.org/docs/cli/commands/test/#the-run-block",
+			Attributes: map[string]*schema.AttributeSchema{
+				"command": {
+					Constraint: schema.OneOf{
+						schema.Keyword{
+							Keyword:     "apply",
+							Description: lang.Markdown("Create real infrastructure (default)"),
+						},
+						schema.Keyword{
+							Keyword:     "plan",
+							Description: lang.Markdown("Only create a plan without applying it"),
+						},
+					},
+					IsOptional:   true,
+					DefaultValue: schema.DefaultValue{Value: cty.StringVal("apply")},
+					Description:  lang.Markdown("Command to execute for this run, `apply` (default) or `plan`"),
+				},
+				"providers": {
+					Constraint: schema.Map{
+						Name: "map of provider references",
+						Elem: schema.Reference{OfScopeId: refscope.ProviderScope},
+					},
+					IsOptional:  true,
+					Description: lang.Markdown("Explicit mapping of providers which the configuration under test uses for this run"),
+				},
+				"expect_failures": {
+					Constraint: schema.Set{
+						Elem: schema.OneOf{
+							schema.Reference{OfScopeId: refscope.VariableScope},
+							schema.Reference{OfScopeId: refscope.OutputScope},
+							schema.Reference{OfScopeId: refscope.ResourceScope},
+							schema.Reference{OfScopeId: refscope.DataScope},
+						},
+					},
+					IsOptional: true,
+					Description: lang.Markdown("Set of references to checkable objects (variables, outputs, resources, data sources) " +
+						"whose custom conditions are expected to fail during this run"),
+				},
+			},
+			Blocks: map[string]*schema.BlockSchema{
+				"variables":    variablesBlockSchema(),
+				"module":       runModuleBlockSchema(),
+				"plan_options": planOptionsBlockSchema(),
+				"assert":       assertBlockSchema(),
+			},
+		},
+	}
+}
+
+func runModuleBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Module},
+		Description: lang.Markdown("Alternative module to execute this run against, " +
+			"instead of the configuration under test"),
+		MaxItems: 1,
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"source": {
+					Constraint: schema.LiteralType{Type: cty.String},
+					Description: lang.Markdown("Source where to load the module from, " +
+						"a local directory (e.g. `./testing/setup`) or a registry address (e.g. `opentofu/example/aws`)"),
+					IsRequired: true,
+					CompletionHooks: lang.CompletionHooks{
+						{
+							Name: "CompleteLocalModuleSources",
+						},
+						{
+							Name: "CompleteRegistryModuleSources",
+						},
+					},
+				},
+				"version": {
+					Constraint: schema.LiteralType{Type: cty.String},
+					IsOptional: true,
+					Description: lang.Markdown("Constraint to set the version of the module, e.g. `~> 1.0`." +
+						" Only applicable to modules in a module registry."),
+					CompletionHooks: lang.CompletionHooks{
+						{
+							Name: "CompleteRegistryModuleVersions",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func planOptionsBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Description: lang.Markdown("Options controlling the plan created for this run"),
+		MaxItems:    1,
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"mode": {
+					Constraint: schema.OneOf{
+						schema.Keyword{
+							Keyword:     "normal",
+							Description: lang.Markdown("Plan changes as usual (default)"),
+						},
+						schema.Keyword{
+							Keyword:     "refresh-only",
+							Description: lang.Markdown("Only update the state to match remote objects"),
+						},
+					},
+					IsOptional:  true,
+					Description: lang.Markdown("Planning mode, `normal` (default) or `refresh-only`"),
+				},
+				"refresh": {
+					Constraint:   schema.LiteralType{Type: cty.Bool},
+					IsOptional:   true,
+					DefaultValue: schema.DefaultValue{Value: cty.True},
+					Description:  lang.Markdown("Whether to refresh the state before planning"),
+				},
+				"replace": {
+					Constraint: schema.Set{
+						Elem: schema.Reference{OfScopeId: refscope.ResourceScope},
+					},
+					IsOptional:  true,
+					Description: lang.Markdown("Set of resource addresses to force replacement of"),
+				},
+				"target": {
+					Constraint: schema.Set{
+						Elem: schema.OneOf{
+							schema.Reference{OfScopeId: refscope.ResourceScope},
+							schema.Reference{OfScopeId: refscope.DataScope},
+							schema.Reference{OfScopeId: refscope.ModuleScope},
+						},
+					},
+					IsOptional:  true,
+					Description: lang.Markdown("Set of addresses to limit the plan to"),
+				},
+			},
+		},
+	}
+}
+
+func assertBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Description: lang.Markdown("Assertion validating the result of the run. " +
+			"A failed assertion marks the run as failed."),
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"condition": {
+					Constraint:  schema.AnyExpression{OfType: cty.Bool},
+					IsRequired:  true,
+					Description: lang.Markdown("Condition to meet for the assertion to pass (any expression which evaluates to boolean)"),
+				},
+				"error_message": {
+					Constraint:  schema.AnyExpression{OfType: cty.String},
+					IsRequired:  true,
+					Description: lang.Markdown("Error message to report when `condition` evaluates to `false`"),
+				},
+			},
+		},
+	}
+}
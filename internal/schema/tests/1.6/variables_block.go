@@ -0,0 +1,26 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/schema/tokmod"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func variablesBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Variable},
+		Description: lang.Markdown("Input variable values passed to the configuration under test, " +
+			"e.g. `bucket_prefix = \"test\"`"),
+		Body: &schema.BodySchema{
+			AnyAttribute: &schema.AttributeSchema{
+				Constraint: schema.AnyExpression{OfType: cty.DynamicPseudoType},
+			},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/schema/refscope"
+	"github.com/opentofu/opentofu-schema/internal/schema/tokmod"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func mockProviderBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Address: &schema.BlockAddrSchema{
+			Steps: []schema.AddrStep{
+				schema.LabelStep{Index: 0},
+				schema.AttrValueStep{Name: "alias", IsOptional: true},
+			},
+			FriendlyName: "provider",
+			ScopeId:      refscope.ProviderScope,
+			AsReference:  true,
+		},
+		SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Provider},
+		Labels: []*schema.LabelSchema{
+			{
+				Name:                   "name",
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Name},
+				Description:            lang.PlainText("Provider Name"),
+				Completable:            true,
+			},
+		},
+		Description: lang.Markdown("A `mock_provider` block replaces a provider with one returning " +
+			"generated values, so runs don't create real infrastructure"),
+		Body: &schema.BodySchema{
+			HoverURL: "https://opentofu.org/docs/cli/commands/test/#the-mock_provider-blocks",
+			Attributes: map[string]*schema.AttributeSchema{
+				"alias": {
+					Constraint:  schema.LiteralType{Type: cty.String},
+					IsOptional:  true,
+					Description: lang.Markdown("Alias for using the same mocked provider with different configurations, e.g. `eu-west`"),
+				},
+			},
+			Blocks: map[string]*schema.BlockSchema{
+				"mock_resource":     mockBlockSchema("Resource Type", "Default values for all resources of the given type"),
+				"mock_data":         mockBlockSchema("Data Source Type", "Default values for all data sources of the given type"),
+				"override_resource": overrideResourceBlockSchema(),
+				"override_data":     overrideDataBlockSchema(),
+			},
+		},
+	}
+}
+
+func mockBlockSchema(labelDescription, description string) *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Labels: []*schema.LabelSchema{
+			{
+				Name:                   "type",
+				SemanticTokenModifiers: lang.SemanticTokenModifiers{tokmod.Type},
+				Description:            lang.PlainText(labelDescription),
+			},
+		},
+		Description: lang.Markdown(description),
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"defaults": {
+					Constraint:  schema.AnyExpression{OfType: cty.DynamicPseudoType},
+					IsOptional:  true,
+					Description: lang.Markdown("Object of values to use for computed attributes instead of generated ones"),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/schema/refscope"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func overrideResourceBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Description: lang.Markdown("Overrides the values of a resource instead of creating it"),
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"target": {
+					Constraint:  schema.Reference{OfScopeId: refscope.ResourceScope},
+					IsRequired:  true,
+					Description: lang.Markdown("Address of the resource to override, e.g. `aws_s3_bucket.test`"),
+				},
+				"values": {
+					Constraint:  schema.AnyExpression{OfType: cty.DynamicPseudoType},
+					IsOptional:  true,
+					Description: lang.Markdown("Object of attribute values to return for the resource"),
+				},
+			},
+		},
+	}
+}
+
+func overrideDataBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Description: lang.Markdown("Overrides the values of a data source instead of reading it"),
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"target": {
+					Constraint:  schema.Reference{OfScopeId: refscope.DataScope},
+					IsRequired:  true,
+					Description: lang.Markdown("Address of the data source to override, e.g. `data.aws_ami.ubuntu`"),
+				},
+				"values": {
+					Constraint:  schema.AnyExpression{OfType: cty.DynamicPseudoType},
+					IsOptional:  true,
+					Description: lang.Markdown("Object of attribute values to return for the data source"),
+				},
+			},
+		},
+	}
+}
+
+func overrideModuleBlockSchema() *schema.BlockSchema {
+	return &schema.BlockSchema{
+		Description: lang.Markdown("Overrides the outputs of a module call instead of executing it"),
+		Body: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"target": {
+					Constraint:  schema.Reference{OfScopeId: refscope.ModuleScope},
+					IsRequired:  true,
+					Description: lang.Markdown("Address of the module call to override, e.g. `module.network`"),
+				},
+				"outputs": {
+					Constraint:  schema.AnyExpression{OfType: cty.DynamicPseudoType},
+					IsOptional:  true,
+					Description: lang.Markdown("Object of output values to return for the module call"),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tfplugin6 contains client and server code of the plugin
+// protocol version 6, limited to retrieval of provider schemas
+package tfplugin6
+
+//go:generate protoc --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:. tfplugin6.proto
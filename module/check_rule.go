@@ -0,0 +1,22 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
+// CheckRule represents a custom condition, such as a validation
+// block within a variable or a precondition block within an output.
+//
+// Both Condition and ErrorMessage are kept as expressions,
+// since they typically refer to values known only during plan.
+type CheckRule struct {
+	Condition    hcl.Expression
+	ErrorMessage hcl.Expression
+
+	DefRangePtr *hcl.Range
+}
@@ -0,0 +1,26 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// IsOverrideFile returns true if the given filename is an override file,
+// i.e. override.tf, *_override.tf or their .tf.json, .tofu and .tofu.json
+// equivalents.
+func IsOverrideFile(filename string) bool {
+	baseName := filepath.Base(filename)
+	for _, ext := range []string{".tofu.json", ".tf.json", ".tofu", ".tf"} {
+		if !strings.HasSuffix(baseName, ext) {
+			continue
+		}
+		baseName = strings.TrimSuffix(baseName, ext)
+		return baseName == "override" || strings.HasSuffix(baseName, "_override")
+	}
+	return false
+}
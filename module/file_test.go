@@ -0,0 +1,31 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"testing"
+)
+
+func TestIsOverrideFile(t *testing.T) {
+	testCases := map[string]bool{
+		"main.tf":                 false,
+		"override.tf":             true,
+		"override.tf.json":        true,
+		"override.tofu":           true,
+		"override.tofu.json":      true,
+		"main_override.tf":        true,
+		"main_override.tofu.json": true,
+		"mainoverride.tf":         false,
+		"override.tfvars":         false,
+		"dir/foo_override.tf":     true,
+	}
+
+	for filename, expected := range testCases {
+		if got := IsOverrideFile(filename); got != expected {
+			t.Errorf("%q: expected %t, got %t", filename, expected, got)
+		}
+	}
+}
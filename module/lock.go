@@ -0,0 +1,34 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+// LockFileName is the name of the dependency lock file
+// maintained by tofu init in the root module
+const LockFileName = ".terraform.lock.hcl"
+
+// ProviderLocks represents providers selected by tofu init,
+// keyed by their address
+type ProviderLocks map[tfaddr.Provider]ProviderLock
+
+// ProviderLock represents a single provider block
+// of the dependency lock file
+type ProviderLock struct {
+	Address tfaddr.Provider
+	Version *version.Version
+	// Constraints represents the version constraints
+	// which were used to select the version
+	Constraints version.Constraints
+	Hashes      []string
+
+	DefRangePtr     *hcl.Range
+	VersionRangePtr *hcl.Range
+}
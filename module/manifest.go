@@ -0,0 +1,130 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// ManifestPath is the path of the manifest of installed modules,
+// which is written by tofu init, relative to the root module
+var ManifestPath = filepath.Join(".terraform", "modules", "modules.json")
+
+// ModuleManifest represents modules installed for a root module
+type ModuleManifest struct {
+	// Records are sorted by their key
+	Records []ModuleManifestRecord
+}
+
+// ModuleManifestRecord represents a single installed module
+type ModuleManifestRecord struct {
+	// Key is the path of module calls from the root module to the
+	// installed module, separated by dots, e.g. "vpc.subnets"
+	Key        string
+	SourceAddr ModuleSourceAddr
+	Version    *version.Version
+	// Dir is the path of the module relative to the root module
+	Dir string
+}
+
+type moduleManifestJSON struct {
+	Records []struct {
+		Key        string `json:"Key"`
+		SourceAddr string `json:"Source"`
+		Version    string `json:"Version,omitempty"`
+		Dir        string `json:"Dir"`
+	} `json:"Modules"`
+}
+
+// ParseModuleManifest parses the manifest of installed modules
+// (see ManifestPath)
+func ParseModuleManifest(b []byte) (*ModuleManifest, error) {
+	var mj moduleManifestJSON
+	if err := json.Unmarshal(b, &mj); err != nil {
+		return nil, fmt.Errorf("failed to parse module manifest: %w", err)
+	}
+
+	records := make([]ModuleManifestRecord, 0, len(mj.Records))
+	for _, r := range mj.Records {
+		record := ModuleManifestRecord{
+			Key: r.Key,
+			Dir: filepath.FromSlash(r.Dir),
+		}
+		if r.SourceAddr != "" {
+			record.SourceAddr = ParseModuleSourceAddr(r.SourceAddr)
+		}
+		if r.Version != "" {
+			v, err := version.NewVersion(r.Version)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version %q of module %q: %w", r.Version, r.Key, err)
+			}
+			record.Version = v
+		}
+		records = append(records, record)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+
+	return &ModuleManifest{
+		Records: records,
+	}, nil
+}
+
+// InstalledModuleCalls returns module calls of installed modules,
+// keyed by their manifest key, e.g. "vpc.subnets"
+func (mm *ModuleManifest) InstalledModuleCalls() map[string]InstalledModuleCall {
+	calls := make(map[string]InstalledModuleCall, len(mm.Records))
+	for _, record := range mm.Records {
+		// The root module is recorded under an empty key
+		if record.Key == "" {
+			continue
+		}
+
+		localName := record.Key
+		if idx := strings.LastIndexByte(localName, '.'); idx != -1 {
+			localName = localName[idx+1:]
+		}
+
+		calls[record.Key] = InstalledModuleCall{
+			LocalName:  localName,
+			SourceAddr: record.SourceAddr,
+			Version:    record.Version,
+			Path:       record.Dir,
+		}
+	}
+	return calls
+}
+
+// InstalledModulePath returns the directory of a module installed from
+// the given normalized source address, relative to the root module.
+//
+// The manifest describes a single root module, so the root path is not
+// used for the lookup. If a module was installed multiple times, e.g.
+// in different versions, the directory with the lowest key is returned.
+func (mm *ModuleManifest) InstalledModulePath(rootPath string, normalizedSource string) (string, bool) {
+	for _, record := range mm.Records {
+		if record.Key == "" || record.SourceAddr == nil {
+			continue
+		}
+		// Local modules are recorded with the source relative
+		// to their caller, which is ambiguous
+		if _, ok := record.SourceAddr.(LocalSourceAddr); ok {
+			continue
+		}
+		if record.SourceAddr.String() == normalizedSource {
+			return record.Dir, true
+		}
+	}
+	return "", false
+}
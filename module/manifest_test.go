@@ -0,0 +1,120 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+const testModuleManifest = `{
+  "Modules": [
+    {
+      "Key": "vpc",
+      "Source": "registry.opentofu.org/terraform-aws-modules/vpc/aws",
+      "Version": "5.1.2",
+      "Dir": ".terraform/modules/vpc"
+    },
+    {
+      "Key": "",
+      "Source": "",
+      "Dir": "."
+    },
+    {
+      "Key": "vpc.subnets",
+      "Source": "./modules/subnets",
+      "Dir": ".terraform/modules/vpc/modules/subnets"
+    },
+    {
+      "Key": "consul",
+      "Source": "git::https://github.com/hashicorp/terraform-aws-consul.git",
+      "Dir": ".terraform/modules/consul"
+    }
+  ]
+}`
+
+func TestParseModuleManifest(t *testing.T) {
+	manifest, err := ParseModuleManifest([]byte(testModuleManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCalls := map[string]InstalledModuleCall{
+		"consul": {
+			LocalName:  "consul",
+			SourceAddr: RemoteSourceAddr("git::https://github.com/hashicorp/terraform-aws-consul.git"),
+			Path:       filepath.Join(".terraform", "modules", "consul"),
+		},
+		"vpc": {
+			LocalName:  "vpc",
+			SourceAddr: tfaddr.MustParseModuleSource("registry.opentofu.org/terraform-aws-modules/vpc/aws"),
+			Version:    version.Must(version.NewVersion("5.1.2")),
+			Path:       filepath.Join(".terraform", "modules", "vpc"),
+		},
+		"vpc.subnets": {
+			LocalName:  "subnets",
+			SourceAddr: LocalSourceAddr("./modules/subnets"),
+			Path:       filepath.Join(".terraform", "modules", "vpc", "modules", "subnets"),
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, manifest.InstalledModuleCalls()); diff != "" {
+		t.Fatalf("unexpected installed module calls: %s", diff)
+	}
+}
+
+func TestModuleManifest_InstalledModulePath(t *testing.T) {
+	manifest, err := ParseModuleManifest([]byte(testModuleManifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		source       string
+		expectedPath string
+		expectedOk   bool
+	}{
+		{
+			ParseModuleSourceAddr("terraform-aws-modules/vpc/aws").String(),
+			filepath.Join(".terraform", "modules", "vpc"),
+			true,
+		},
+		{
+			ParseModuleSourceAddr("github.com/hashicorp/terraform-aws-consul").String(),
+			filepath.Join(".terraform", "modules", "consul"),
+			true,
+		},
+		{
+			"./modules/subnets",
+			"",
+			false,
+		},
+		{
+			ParseModuleSourceAddr("terraform-aws-modules/eks/aws").String(),
+			"",
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.source, func(t *testing.T) {
+			path, ok := manifest.InstalledModulePath(".", tc.source)
+			if ok != tc.expectedOk || path != tc.expectedPath {
+				t.Fatalf("expected (%q, %t), given (%q, %t)", tc.expectedPath, tc.expectedOk, path, ok)
+			}
+		})
+	}
+}
+
+func TestParseModuleManifest_invalid(t *testing.T) {
+	_, err := ParseModuleManifest([]byte(`{"Modules": [{"Key": "vpc", "Version": "foo"}]}`))
+	if err == nil {
+		t.Fatal("expected invalid version to fail")
+	}
+}
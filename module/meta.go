@@ -7,6 +7,7 @@ package module
 
 import (
 	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu-schema/backend"
 	tfaddr "github.com/opentofu/registry-address"
 )
@@ -14,15 +15,37 @@ import (
 type Meta struct {
 	Path      string
 	Filenames []string
+	// ShadowedFilenames lists files which were ignored because a file
+	// of the same name with .tofu (or .tofu.json) extension takes
+	// precedence, e.g. main.tf when main.tofu is present.
+	ShadowedFilenames []string
 
 	CoreRequirements     version.Constraints
 	Backend              *Backend
 	Cloud                *backend.Cloud
 	ProviderReferences   map[ProviderRef]tfaddr.Provider
 	ProviderRequirements ProviderRequirements
+	ProviderConfigs      map[ProviderRef]ProviderConfig
 	Variables            map[string]Variable
 	Outputs              map[string]Output
 	ModuleCalls          map[string]DeclaredModuleCall
+
+	// Resources, DataSources and EphemeralResources are keyed
+	// by their address, e.g. aws_instance.foo, data.aws_ami.foo
+	// or ephemeral.random_password.foo respectively.
+	Resources          map[string]Resource
+	DataSources        map[string]Resource
+	EphemeralResources map[string]Resource
+
+	// DeclaredProviderRequirements lists entries of all required_providers
+	// blocks in the order of their appearance.
+	DeclaredProviderRequirements []DeclaredProviderRequirement
+
+	// Moved, Imports and Removed list the refactoring
+	// blocks in the order of their appearance.
+	Moved   []Moved
+	Imports []Import
+	Removed []Removed
 }
 
 type ProviderRequirements map[tfaddr.Provider]version.Constraints
@@ -48,6 +71,9 @@ func (pr ProviderRequirements) Equals(reqs ProviderRequirements) bool {
 type Backend struct {
 	Type string
 	Data backend.BackendData
+
+	DefRangePtr  *hcl.Range
+	NameRangePtr *hcl.Range
 }
 
 func (be *Backend) Equals(b *Backend) bool {
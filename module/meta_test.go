@@ -87,6 +87,85 @@ func TestBackend_Equals(t *testing.T) {
 			},
 			false,
 		},
+		{
+			&Backend{
+				Type: "s3",
+				Data: &backend.S3{
+					Bucket: "state",
+					Key:    "terraform.tfstate",
+				},
+			},
+			&Backend{
+				Type: "s3",
+				Data: &backend.S3{
+					Bucket: "state",
+					Key:    "terraform.tfstate",
+				},
+			},
+			true,
+		},
+		{
+			&Backend{
+				Type: "s3",
+				Data: &backend.S3{
+					Bucket: "state",
+					Key:    "terraform.tfstate",
+				},
+			},
+			&Backend{
+				Type: "s3",
+				Data: &backend.S3{
+					Bucket: "state",
+					Key:    "network/terraform.tfstate",
+				},
+			},
+			false,
+		},
+		{
+			&Backend{
+				Type: "s3",
+				Data: &backend.S3{},
+			},
+			&Backend{
+				Type: "s3",
+				Data: &backend.GCS{},
+			},
+			false,
+		},
+		{
+			&Backend{
+				Type: "kubernetes",
+				Data: &backend.Kubernetes{
+					SecretSuffix: "state",
+					Labels:       map[string]string{"team": "a"},
+				},
+			},
+			&Backend{
+				Type: "kubernetes",
+				Data: (&backend.Kubernetes{
+					SecretSuffix: "state",
+					Labels:       map[string]string{"team": "a"},
+				}).Copy(),
+			},
+			true,
+		},
+		{
+			&Backend{
+				Type: "kubernetes",
+				Data: &backend.Kubernetes{
+					SecretSuffix: "state",
+					Labels:       map[string]string{"team": "a"},
+				},
+			},
+			&Backend{
+				Type: "kubernetes",
+				Data: &backend.Kubernetes{
+					SecretSuffix: "state",
+					Labels:       map[string]string{"team": "b"},
+				},
+			},
+			false,
+		},
 	}
 
 	for i, tc := range testCases {
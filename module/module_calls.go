@@ -6,6 +6,8 @@
 package module
 
 import (
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-version"
@@ -39,7 +41,25 @@ type DeclaredModuleCall struct {
 	SourceAddr    ModuleSourceAddr
 	Version       version.Constraints
 	InputNames    []string
-	RangePtr      *hcl.Range
+
+	// Inputs represents the arguments setting variables
+	// of the called module, keyed by their name
+	Inputs map[string]ModuleCallInput
+
+	// Providers maps provider configurations of the child module
+	// to the configurations of the calling module passed to it,
+	// as declared in the providers argument
+	Providers map[ProviderRef]ProviderRef
+
+	HasCount   bool
+	HasForEach bool
+
+	// DependsOn contains references from the depends_on argument
+	DependsOn []string
+
+	RangePtr     *hcl.Range
+	DefRangePtr  *hcl.Range
+	NameRangePtr *hcl.Range
 
 	// Store the source address so that we can match against it later
 	// it's not always static!
@@ -50,12 +70,25 @@ func (mc DeclaredModuleCall) Copy() DeclaredModuleCall {
 	inputNames := make([]string, len(mc.InputNames))
 	copy(inputNames, mc.InputNames)
 
+	var inputs map[string]ModuleCallInput
+	if mc.Inputs != nil {
+		inputs = make(map[string]ModuleCallInput, len(mc.Inputs))
+		for name, input := range mc.Inputs {
+			inputs[name] = input.Copy()
+		}
+	}
+
 	newModuleCall := DeclaredModuleCall{
 		LocalName:      mc.LocalName,
 		RawSourceAddr:  mc.RawSourceAddr,
 		SourceAddr:     mc.SourceAddr,
 		Version:        mc.Version,
 		InputNames:     inputNames,
+		Inputs:         inputs,
+		Providers:      maps.Clone(mc.Providers),
+		HasCount:       mc.HasCount,
+		HasForEach:     mc.HasForEach,
+		DependsOn:      slices.Clone(mc.DependsOn),
 		SourceAddrExpr: mc.SourceAddrExpr,
 	}
 
@@ -63,10 +96,44 @@ func (mc DeclaredModuleCall) Copy() DeclaredModuleCall {
 		rangeCpy := *mc.RangePtr
 		newModuleCall.RangePtr = &rangeCpy
 	}
+	if mc.DefRangePtr != nil {
+		rangeCpy := *mc.DefRangePtr
+		newModuleCall.DefRangePtr = &rangeCpy
+	}
+	if mc.NameRangePtr != nil {
+		rangeCpy := *mc.NameRangePtr
+		newModuleCall.NameRangePtr = &rangeCpy
+	}
 
 	return newModuleCall
 }
 
+// ModuleCallInput represents an argument of a module call
+// which sets a variable of the called module
+type ModuleCallInput struct {
+	Expr hcl.Expression
+
+	NameRangePtr  *hcl.Range
+	ValueRangePtr *hcl.Range
+}
+
+func (mci ModuleCallInput) Copy() ModuleCallInput {
+	newInput := ModuleCallInput{
+		Expr: mci.Expr,
+	}
+
+	if mci.NameRangePtr != nil {
+		rangeCpy := *mci.NameRangePtr
+		newInput.NameRangePtr = &rangeCpy
+	}
+	if mci.ValueRangePtr != nil {
+		rangeCpy := *mci.ValueRangePtr
+		newInput.ValueRangePtr = &rangeCpy
+	}
+
+	return newInput
+}
+
 type ModuleSourceAddr interface {
 	ForDisplay() string
 	String() string
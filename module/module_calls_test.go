@@ -0,0 +1,56 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestDeclaredModuleCall_Copy(t *testing.T) {
+	mc := DeclaredModuleCall{
+		LocalName:  "net",
+		InputNames: []string{"cidr"},
+		Inputs: map[string]ModuleCallInput{
+			"cidr": {
+				NameRangePtr: &hcl.Range{
+					Filename: "main.tf",
+					Start:    hcl.Pos{Line: 3, Column: 3, Byte: 20},
+					End:      hcl.Pos{Line: 3, Column: 7, Byte: 24},
+				},
+				ValueRangePtr: &hcl.Range{
+					Filename: "main.tf",
+					Start:    hcl.Pos{Line: 3, Column: 10, Byte: 27},
+					End:      hcl.Pos{Line: 3, Column: 23, Byte: 40},
+				},
+			},
+		},
+		Providers: map[ProviderRef]ProviderRef{
+			{LocalName: "aws"}: {LocalName: "aws", Alias: "west"},
+		},
+		DependsOn: []string{"module.db"},
+	}
+
+	mcCopy := mc.Copy()
+	mcCopy.Inputs["cidr"].NameRangePtr.Filename = "other.tf"
+	mcCopy.Inputs["extra"] = ModuleCallInput{}
+	mcCopy.Providers[ProviderRef{LocalName: "google"}] = ProviderRef{LocalName: "google"}
+	mcCopy.DependsOn[0] = "module.other"
+
+	if mc.Inputs["cidr"].NameRangePtr.Filename != "main.tf" {
+		t.Fatal("expected input name range to be copied")
+	}
+	if len(mc.Inputs) != 1 {
+		t.Fatal("expected inputs to be copied")
+	}
+	if len(mc.Providers) != 1 {
+		t.Fatal("expected providers to be copied")
+	}
+	if mc.DependsOn[0] != "module.db" {
+		t.Fatal("expected depends_on to be copied")
+	}
+}
@@ -6,15 +6,38 @@
 package module
 
 import (
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 )
 
 type Output struct {
 	Description string
 	IsSensitive bool
-	Value       cty.Value
+
+	// Value is the statically evaluated value if it is wholly
+	// known, else cty.NilVal
+	Value cty.Value
 
 	// Deprecated is a string to mark an output as deprecated with instructions to end users
 	// of the module.
 	Deprecated string
+
+	// Type is the type of the value, inferred statically from
+	// the value expression. It is cty.DynamicPseudoType if the type
+	// cannot be inferred, e.g. when the value refers to a resource.
+	Type cty.Type
+
+	// IsEphemeral reflects whether the output is ephemeral,
+	// available since OpenTofu v1.11
+	IsEphemeral bool
+
+	// DependsOn lists the references from the depends_on argument,
+	// e.g. aws_instance.foo or module.bar
+	DependsOn []string
+
+	// Preconditions represents any precondition blocks
+	Preconditions []CheckRule
+
+	DefRangePtr  *hcl.Range
+	NameRangePtr *hcl.Range
 }
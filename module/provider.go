@@ -0,0 +1,33 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+// ProviderConfig represents a provider block declared in a module
+type ProviderConfig struct {
+	LocalName string
+	Alias     string
+
+	DefRangePtr  *hcl.Range
+	NameRangePtr *hcl.Range
+}
+
+// DeclaredProviderRequirement represents a single entry
+// of a required_providers block.
+//
+// Unlike ProviderRequirements, declarations are not merged, so the same
+// provider may be declared (and hence listed) more than once.
+type DeclaredProviderRequirement struct {
+	LocalName string
+	Source    tfaddr.Provider
+
+	DefRangePtr  *hcl.Range
+	NameRangePtr *hcl.Range
+}
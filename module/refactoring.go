@@ -0,0 +1,59 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Moved represents a moved block declared in a module.
+//
+// From and To hold the addresses in their canonical
+// string form, e.g. module.net["a"].aws_subnet.this[0]
+type Moved struct {
+	From string
+	To   string
+
+	DefRangePtr  *hcl.Range
+	FromRangePtr *hcl.Range
+	ToRangePtr   *hcl.Range
+}
+
+// Import represents an import block declared in a module
+type Import struct {
+	// To holds the target address in its canonical string form.
+	// Addresses which contain references (e.g. to each.key)
+	// are kept as they are written in the configuration.
+	To string
+
+	// ID is the import ID, if it is a static string
+	ID string
+
+	// Provider is set if the provider configuration is explicitly
+	// referenced via the provider argument
+	Provider *ProviderRef
+
+	// HasForEach reflects whether the for_each
+	// meta-argument is set for the block
+	HasForEach bool
+
+	DefRangePtr *hcl.Range
+	ToRangePtr  *hcl.Range
+	IDRangePtr  *hcl.Range
+}
+
+// Removed represents a removed block declared in a module
+type Removed struct {
+	// From holds the address in its canonical string form
+	From string
+
+	// Destroy reflects the destroy argument of the nested
+	// lifecycle block, available since OpenTofu v1.10
+	Destroy bool
+
+	DefRangePtr  *hcl.Range
+	FromRangePtr *hcl.Range
+}
@@ -0,0 +1,39 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Resource represents a managed resource, data source
+// or ephemeral resource declared in a module
+type Resource struct {
+	Type     string
+	Name     string
+	Provider ProviderRef
+
+	// HasCount and HasForEach reflect whether the count
+	// or for_each meta-argument is set for the block
+	HasCount   bool
+	HasForEach bool
+
+	DefRangePtr  *hcl.Range
+	NameRangePtr *hcl.Range
+}
+
+func (r Resource) Copy() Resource {
+	newResource := r
+	if r.DefRangePtr != nil {
+		rangeCpy := *r.DefRangePtr
+		newResource.DefRangePtr = &rangeCpy
+	}
+	if r.NameRangePtr != nil {
+		rangeCpy := *r.NameRangePtr
+		newResource.NameRangePtr = &rangeCpy
+	}
+	return newResource
+}
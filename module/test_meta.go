@@ -0,0 +1,45 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package module
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// TestMeta represents the early decoded content of a single test file
+// (*.tftest.hcl or *.tofutest.hcl). Each test file is an independent
+// test suite executed by `tofu test`.
+type TestMeta struct {
+	Path     string
+	Filename string
+
+	Runs          []TestRun
+	ProviderRefs  []ProviderRef
+	MockProviders []ProviderRef
+}
+
+// TestCommand represents the command a run block executes
+type TestCommand string
+
+const (
+	TestCommandApply TestCommand = "apply"
+	TestCommandPlan  TestCommand = "plan"
+)
+
+// TestRun represents a run block, in the order of declaration
+type TestRun struct {
+	Name    string
+	Command TestCommand
+
+	// RawModuleSource and ModuleSource are only set if the run
+	// executes against an alternative module via the module block.
+	RawModuleSource string
+	ModuleSource    ModuleSourceAddr
+	ModuleVersion   version.Constraints
+
+	RangePtr *hcl.Range
+}
@@ -6,6 +6,7 @@
 package module
 
 import (
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -32,4 +33,18 @@ type Variable struct {
 	// Deprecated is a string to mark a variable as deprecated with instructions to end users
 	// of the module.
 	Deprecated string
+
+	// IsNullable reflects whether null is an acceptable value
+	// for the variable, which is the default
+	IsNullable bool
+
+	// IsEphemeral reflects whether the variable is ephemeral,
+	// available since OpenTofu v1.11
+	IsEphemeral bool
+
+	// Validations represents any custom validation rules
+	Validations []CheckRule
+
+	DefRangePtr  *hcl.Range
+	NameRangePtr *hcl.Range
 }
@@ -0,0 +1,286 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package moduletree
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/opentofu/opentofu-schema/earlydecoder"
+	"github.com/opentofu/opentofu-schema/module"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+// FS provides access to files of modules
+type FS interface {
+	ReadDir(name string) ([]fs.DirEntry, error)
+	ReadFile(name string) ([]byte, error)
+}
+
+// InstalledModules resolves directories of modules installed
+// by tofu init, relative to the root module
+type InstalledModules interface {
+	// InstalledModuleDir returns the directory of the module installed
+	// for the module call with the given key, e.g. "vpc.subnets"
+	InstalledModuleDir(key string) (string, bool)
+}
+
+type Loader struct {
+	fs        FS
+	installed InstalledModules
+}
+
+// NewLoader returns a loader reading modules from the given filesystem,
+// or from the OS filesystem if it is nil
+func NewLoader(fsys FS) *Loader {
+	if fsys == nil {
+		fsys = osFS{}
+	}
+	return &Loader{
+		fs: fsys,
+	}
+}
+
+// SetInstalledModules sets the lookup of installed modules, which is
+// used to follow module calls with registry or remote sources.
+// If no lookup is set, the manifest of installed modules
+// in the root module (.terraform/modules/modules.json) is read.
+func (l *Loader) SetInstalledModules(im InstalledModules) {
+	l.installed = im
+}
+
+// LoadTree decodes the module at the given root path and all modules
+// called from it. Module calls which cannot be followed, e.g. because
+// the source is not known statically or the module is not installed,
+// and module calls introducing a cycle are reported as diagnostics.
+func (l *Loader) LoadTree(rootPath string) (*ModuleTree, hcl.Diagnostics) {
+	tl := &treeLoader{
+		Loader:    l,
+		installed: l.installed,
+		rootPath:  filepath.Clean(rootPath),
+		tree: &ModuleTree{
+			Nodes: make(map[string]*Node),
+		},
+		visiting: make(map[string]bool),
+	}
+
+	if tl.installed == nil {
+		tl.installed = tl.readManifest()
+	}
+
+	root, diags := tl.loadNode(tl.rootPath, "")
+	tl.tree.Root = root
+
+	return tl.tree, diags
+}
+
+type treeLoader struct {
+	*Loader
+	installed InstalledModules
+	rootPath  string
+	tree      *ModuleTree
+
+	// visiting contains paths of modules on the path
+	// from the root module to the module being loaded
+	visiting map[string]bool
+}
+
+// loadNode loads the module at the given path, called via module
+// calls with the given key, which is empty for the root module
+func (tl *treeLoader) loadNode(modPath string, key string) (*Node, hcl.Diagnostics) {
+	if node, ok := tl.tree.Nodes[modPath]; ok {
+		return node, nil
+	}
+
+	meta, diags := tl.LoadModule(modPath)
+	if meta == nil {
+		return nil, diags
+	}
+
+	node := &Node{
+		Path:  modPath,
+		Meta:  meta,
+		Calls: make(map[string]*Call),
+	}
+	tl.tree.Nodes[modPath] = node
+
+	tl.visiting[modPath] = true
+	defer delete(tl.visiting, modPath)
+
+	names := make([]string, 0, len(meta.ModuleCalls))
+	for name := range meta.ModuleCalls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		mc := meta.ModuleCalls[name]
+
+		childKey := callKey(key, name)
+		childPath, ok := tl.resolveChildPath(modPath, childKey, mc)
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Unresolvable module source",
+				Detail:   fmt.Sprintf("Unable to find the source of module %q. The source may not be known statically, or the module may not be installed.", name),
+				Subject:  mc.DefRangePtr,
+			})
+			continue
+		}
+
+		if tl.visiting[childPath] {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Module call cycle",
+				Detail:   fmt.Sprintf("Module %q calls the module at %s, which is already one of its calling modules.", name, childPath),
+				Subject:  mc.DefRangePtr,
+			})
+			continue
+		}
+
+		child, childDiags := tl.loadNode(childPath, childKey)
+		diags = append(diags, childDiags...)
+		if child == nil {
+			continue
+		}
+
+		node.Calls[name] = &Call{
+			Name:       name,
+			Parent:     node,
+			Child:      child,
+			HasCount:   mc.HasCount,
+			HasForEach: mc.HasForEach,
+		}
+	}
+
+	return node, diags
+}
+
+// readManifest returns the manifest of modules installed for
+// the root module, or nil if there is none
+func (tl *treeLoader) readManifest() InstalledModules {
+	b, err := tl.fs.ReadFile(filepath.Join(tl.rootPath, manifestPath))
+	if err != nil {
+		return nil
+	}
+	manifest, err := parseInstalledManifest(b)
+	if err != nil {
+		return nil
+	}
+	return manifest
+}
+
+// resolveChildPath returns the path of the module called by the given
+// module call with the given key from the module at the given path.
+//
+// Installed modules are looked up by the key rather than the source,
+// since the same source may be installed in different versions.
+func (tl *treeLoader) resolveChildPath(modPath string, key string, mc module.DeclaredModuleCall) (string, bool) {
+	switch sourceAddr := mc.SourceAddr.(type) {
+	case module.LocalSourceAddr:
+		return filepath.Join(modPath, sourceAddr.String()), true
+	case tfaddr.Module, module.RemoteSourceAddr:
+		if tl.installed == nil {
+			return "", false
+		}
+		installedDir, ok := tl.installed.InstalledModuleDir(key)
+		if !ok {
+			return "", false
+		}
+		return filepath.Join(tl.rootPath, installedDir), true
+	}
+
+	return "", false
+}
+
+// callKey returns the key of the module call with the given name,
+// as recorded in the manifest of installed modules
+func callKey(parentKey string, name string) string {
+	if parentKey == "" {
+		return name
+	}
+	return parentKey + "." + name
+}
+
+// LoadModule parses and decodes files of the module at the given path,
+// without following any module calls. Meta is nil only if the module
+// directory cannot be read.
+func (l *Loader) LoadModule(modPath string) (*module.Meta, hcl.Diagnostics) {
+	entries, err := l.fs.ReadDir(modPath)
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read module directory",
+				Detail:   fmt.Sprintf("Module directory %s could not be read: %s", modPath, err),
+			},
+		}
+	}
+
+	var diags hcl.Diagnostics
+	files := make(map[string]*hcl.File)
+	for _, entry := range entries {
+		if entry.IsDir() || !isModuleFilename(entry.Name()) {
+			continue
+		}
+
+		src, err := l.fs.ReadFile(filepath.Join(modPath, entry.Name()))
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read file",
+				Detail:   fmt.Sprintf("File %s could not be read: %s", filepath.Join(modPath, entry.Name()), err),
+			})
+			continue
+		}
+
+		var f *hcl.File
+		var pDiags hcl.Diagnostics
+		if strings.HasSuffix(entry.Name(), ".json") {
+			f, pDiags = json.Parse(src, entry.Name())
+		} else {
+			f, pDiags = hclsyntax.ParseConfig(src, entry.Name(), hcl.InitialPos)
+		}
+		diags = append(diags, pDiags...)
+		if f != nil {
+			files[entry.Name()] = f
+		}
+	}
+
+	meta, mDiags := earlydecoder.LoadModule(modPath, files)
+	diags = append(diags, mDiags...)
+
+	return meta, diags
+}
+
+func isModuleFilename(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	for _, ext := range []string{".tf", ".tf.json", ".tofu", ".tofu.json"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+type osFS struct{}
+
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (osFS) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
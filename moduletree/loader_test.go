@@ -0,0 +1,239 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package moduletree
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoader_LoadTree(t *testing.T) {
+	fsys := fstest.MapFS{
+		"root/main.tf": {Data: []byte(`
+module "network" {
+  source = "./modules/network"
+  count  = 2
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+
+module "missing" {
+  source = "terraform-aws-modules/missing/aws"
+}
+`)},
+		"root/variables.tf.json": {Data: []byte(`{"variable": {"env": {}}}`)},
+		"root/README.md":         {Data: []byte(`# Root`)},
+		"root/modules/network/main.tofu": {Data: []byte(`
+module "subnet" {
+  source   = "../subnet"
+  for_each = toset(["a", "b"])
+}
+`)},
+		"root/modules/subnet/main.tf": {Data: []byte(`
+variable "cidr" {}
+`)},
+		"root/.terraform/modules/vpc/main.tf": {Data: []byte(`
+module "subnet" {
+  source = "../../../modules/subnet"
+}
+`)},
+	}
+
+	loader := NewLoader(fsys)
+	loader.SetInstalledModules(installedModules{
+		"vpc": filepath.Join(".terraform", "modules", "vpc"),
+	})
+
+	tree, diags := loader.LoadTree("root")
+
+	expectedFindings := []string{`Unresolvable module source: Unable to find the source of module "missing". The source may not be known statically, or the module may not be installed.`}
+	findings := make([]string, 0)
+	for _, diag := range diags {
+		findings = append(findings, diag.Summary+": "+diag.Detail)
+	}
+	if diff := cmp.Diff(expectedFindings, findings); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+
+	expectedPaths := []string{
+		"root",
+		filepath.Join("root", ".terraform", "modules", "vpc"),
+		filepath.Join("root", "modules", "network"),
+		filepath.Join("root", "modules", "subnet"),
+	}
+	paths := make([]string, 0, len(tree.Nodes))
+	for path := range tree.Nodes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Fatalf("unexpected modules: %s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"main.tf", "variables.tf.json"}, tree.Root.Meta.Filenames); diff != "" {
+		t.Fatalf("unexpected root module files: %s", diff)
+	}
+
+	network := tree.Root.Calls["network"]
+	if network == nil || !network.HasCount || network.HasForEach || network.Parent != tree.Root {
+		t.Fatalf("unexpected network call: %#v", network)
+	}
+	subnet := network.Child.Calls["subnet"]
+	if subnet == nil || subnet.HasCount || !subnet.HasForEach {
+		t.Fatalf("unexpected subnet call: %#v", subnet)
+	}
+	if _, ok := subnet.Child.Meta.Variables["cidr"]; !ok {
+		t.Fatal("expected subnet module to be decoded")
+	}
+
+	vpc := tree.Root.Calls["vpc"]
+	if vpc == nil {
+		t.Fatal("expected installed vpc module to be loaded")
+	}
+	// The subnet module is decoded once, despite being called twice
+	if vpc.Child.Calls["subnet"].Child != subnet.Child {
+		t.Fatal("expected both calls to share the subnet module")
+	}
+
+	if _, ok := tree.Root.Calls["missing"]; ok {
+		t.Fatal("expected unresolvable module call to be skipped")
+	}
+}
+
+func TestLoader_LoadTree_manifest(t *testing.T) {
+	fsys := fstest.MapFS{
+		"root/main.tf": {Data: []byte(`
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}
+
+module "legacy_vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "4.0.0"
+}
+`)},
+		"root/.terraform/modules/modules.json": {Data: []byte(`{"Modules": [
+  {"Key": "", "Source": "", "Dir": "."},
+  {"Key": "legacy_vpc", "Source": "registry.opentofu.org/terraform-aws-modules/vpc/aws", "Version": "4.0.0", "Dir": ".terraform/modules/legacy_vpc"},
+  {"Key": "vpc", "Source": "registry.opentofu.org/terraform-aws-modules/vpc/aws", "Version": "5.0.0", "Dir": ".terraform/modules/vpc"},
+  {"Key": "vpc.endpoints", "Source": "registry.opentofu.org/terraform-aws-modules/vpc/aws//modules/vpc-endpoints", "Version": "5.0.0", "Dir": ".terraform/modules/vpc.endpoints/modules/vpc-endpoints"}
+]}`)},
+		"root/.terraform/modules/vpc/main.tf": {Data: []byte(`
+variable "cidr" {}
+
+module "endpoints" {
+  source  = "terraform-aws-modules/vpc/aws//modules/vpc-endpoints"
+  version = "5.0.0"
+}
+`)},
+		"root/.terraform/modules/vpc.endpoints/modules/vpc-endpoints/main.tf": {Data: []byte(`
+variable "vpc_id" {}
+`)},
+		"root/.terraform/modules/legacy_vpc/main.tf": {Data: []byte(`
+variable "cidr_block" {}
+`)},
+	}
+
+	tree, diags := NewLoader(fsys).LoadTree("root")
+	if len(diags) > 0 {
+		t.Fatal(diags)
+	}
+
+	vpc := tree.Root.Calls["vpc"]
+	if vpc == nil {
+		t.Fatal("expected installed vpc module to be loaded")
+	}
+	if vpc.Child.Path != filepath.Join("root", ".terraform", "modules", "vpc") {
+		t.Fatalf("unexpected path of vpc module: %q", vpc.Child.Path)
+	}
+
+	// Calls of the same source are resolved by their key,
+	// since each may be installed in a different version
+	legacyVpc := tree.Root.Calls["legacy_vpc"]
+	if legacyVpc == nil {
+		t.Fatal("expected installed legacy_vpc module to be loaded")
+	}
+	if legacyVpc.Child.Path != filepath.Join("root", ".terraform", "modules", "legacy_vpc") {
+		t.Fatalf("unexpected path of legacy_vpc module: %q", legacyVpc.Child.Path)
+	}
+
+	endpoints := vpc.Child.Calls["endpoints"]
+	if endpoints == nil {
+		t.Fatal("expected nested installed endpoints module to be loaded")
+	}
+	if _, ok := endpoints.Child.Meta.Variables["vpc_id"]; !ok {
+		t.Fatal("expected endpoints module to be decoded")
+	}
+}
+
+func TestLoader_LoadTree_cycle(t *testing.T) {
+	fsys := fstest.MapFS{
+		"root/main.tf": {Data: []byte(`
+module "a" {
+  source = "./a"
+}
+`)},
+		"root/a/main.tf": {Data: []byte(`
+module "b" {
+  source = "../b"
+}
+`)},
+		"root/b/main.tf": {Data: []byte(`
+module "a" {
+  source = "../a"
+}
+`)},
+	}
+
+	tree, diags := NewLoader(fsys).LoadTree("root")
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, %d given: %s", len(diags), diags)
+	}
+	if diags[0].Summary != "Module call cycle" {
+		t.Fatalf("unexpected diagnostic: %s", diags[0])
+	}
+	if diags[0].Subject == nil || diags[0].Subject.Filename != "main.tf" || diags[0].Subject.Start.Line != 2 {
+		t.Fatalf("unexpected diagnostic subject: %#v", diags[0].Subject)
+	}
+
+	b := tree.Root.Calls["a"].Child.Calls["b"].Child
+	if len(b.Calls) != 0 {
+		t.Fatalf("expected cyclic call to be skipped, given %#v", b.Calls)
+	}
+}
+
+func TestLoader_LoadTree_missingDirectory(t *testing.T) {
+	fsys := fstest.MapFS{
+		"root/main.tf": {Data: []byte(`
+module "a" {
+  source = "./a"
+}
+`)},
+	}
+
+	tree, diags := NewLoader(fsys).LoadTree("root")
+	if len(diags) != 1 || diags[0].Summary != "Failed to read module directory" {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+	if len(tree.Root.Calls) != 0 {
+		t.Fatalf("expected no calls, given %#v", tree.Root.Calls)
+	}
+}
+
+type installedModules map[string]string
+
+func (im installedModules) InstalledModuleDir(key string) (string, bool) {
+	dir, ok := im[key]
+	return dir, ok
+}
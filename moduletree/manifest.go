@@ -0,0 +1,47 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package moduletree
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+// manifestPath is the path of the manifest of installed modules,
+// which is written by tofu init, relative to the root module
+var manifestPath = filepath.Join(".terraform", "modules", "modules.json")
+
+// installedManifest maps keys of module calls recorded in the manifest
+// of installed modules, e.g. "vpc.subnets", to directories of the
+// installed modules, relative to the root module
+type installedManifest map[string]string
+
+func parseInstalledManifest(b []byte) (installedManifest, error) {
+	var mj struct {
+		Records []struct {
+			Key string `json:"Key"`
+			Dir string `json:"Dir"`
+		} `json:"Modules"`
+	}
+	if err := json.Unmarshal(b, &mj); err != nil {
+		return nil, err
+	}
+
+	manifest := make(installedManifest, len(mj.Records))
+	for _, r := range mj.Records {
+		// The root module is recorded under an empty key
+		if r.Key == "" {
+			continue
+		}
+		manifest[r.Key] = filepath.FromSlash(r.Dir)
+	}
+	return manifest, nil
+}
+
+func (m installedManifest) InstalledModuleDir(key string) (string, bool) {
+	dir, ok := m[key]
+	return dir, ok
+}
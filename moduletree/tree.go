@@ -0,0 +1,43 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package moduletree loads the tree of modules called from a root module
+package moduletree
+
+import (
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+// ModuleTree represents the root module and all modules
+// called from it, directly or indirectly
+type ModuleTree struct {
+	Root *Node
+
+	// Nodes contains all decoded modules, keyed by their path.
+	// A module called from multiple places is decoded only once.
+	Nodes map[string]*Node
+}
+
+// Node represents a single module in the tree
+type Node struct {
+	Path string
+	Meta *module.Meta
+
+	// Calls represents edges to the called modules, keyed by the name
+	// of the module call. Module calls which cannot be resolved, or
+	// which would introduce a cycle, are not present.
+	Calls map[string]*Call
+}
+
+// Call represents an edge between a calling (parent)
+// and a called (child) module
+type Call struct {
+	Name   string
+	Parent *Node
+	Child  *Node
+
+	HasCount   bool
+	HasForEach bool
+}
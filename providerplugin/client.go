@@ -0,0 +1,158 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providerplugin reads provider schemas directly from provider
+// plugin binaries, using the gRPC plugin protocol version 5 or 6
+package providerplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/opentofu/opentofu-schema/internal/tfplugin5"
+	"github.com/opentofu/opentofu-schema/internal/tfplugin6"
+	"github.com/opentofu/opentofu-schema/schema"
+	tfaddr "github.com/opentofu/registry-address"
+	"google.golang.org/grpc"
+)
+
+// Handshake is the handshake configuration shared by OpenTofu
+// and provider plugins
+var Handshake = plugin.HandshakeConfig{
+	// ProtocolVersion is only used by plugins which do not
+	// negotiate the protocol version
+	ProtocolVersion:  5,
+	MagicCookieKey:   "TF_PLUGIN_MAGIC_COOKIE",
+	MagicCookieValue: "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2",
+}
+
+// providerPluginName is the name under which
+// providers are dispensed by the plugin client
+const providerPluginName = "provider"
+
+// maxRecvSize is the maximum size of schema responses,
+// which can be large for providers with many resources
+const maxRecvSize = 64 << 20
+
+var versionedPlugins = map[int]plugin.PluginSet{
+	5: {providerPluginName: &grpcProvider5{}},
+	6: {providerPluginName: &grpcProvider6{}},
+}
+
+// LoadProviderSchema launches the provider plugin binary at the given path,
+// requests its schema and stops the plugin again.
+func LoadProviderSchema(ctx context.Context, binaryPath string, pAddr tfaddr.Provider) (*schema.ProviderSchema, error) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  Handshake,
+		VersionedPlugins: versionedPlugins,
+		Cmd:              exec.CommandContext(ctx, binaryPath),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		AutoMTLS:         true,
+		Logger:           hclog.NewNullLogger(),
+	})
+	defer client.Kill()
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch provider %s: %w", pAddr, err)
+	}
+
+	raw, err := rpcClient.Dispense(providerPluginName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dispense provider %s: %w", pAddr, err)
+	}
+
+	var jsonSchema *tfjson.ProviderSchema
+	switch provider := raw.(type) {
+	case tfplugin5.ProviderClient:
+		resp, err := provider.GetSchema(ctx, &tfplugin5.GetProviderSchema_Request{}, grpc.MaxCallRecvMsgSize(maxRecvSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schema of provider %s: %w", pAddr, err)
+		}
+		if err := diagnosticsErr5(resp.Diagnostics); err != nil {
+			return nil, fmt.Errorf("provider %s returned errors: %w", pAddr, err)
+		}
+		jsonSchema, err = providerSchemaFromProto5(resp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema of provider %s: %w", pAddr, err)
+		}
+	case tfplugin6.ProviderClient:
+		resp, err := provider.GetProviderSchema(ctx, &tfplugin6.GetProviderSchema_Request{}, grpc.MaxCallRecvMsgSize(maxRecvSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get schema of provider %s: %w", pAddr, err)
+		}
+		if err := diagnosticsErr6(resp.Diagnostics); err != nil {
+			return nil, fmt.Errorf("provider %s returned errors: %w", pAddr, err)
+		}
+		jsonSchema, err = providerSchemaFromProto6(resp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema of provider %s: %w", pAddr, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported client of provider %s: %T", pAddr, raw)
+	}
+
+	return schema.ProviderSchemaFromJson(jsonSchema, pAddr), nil
+}
+
+func diagnosticsErr5(diags []*tfplugin5.Diagnostic) error {
+	summaries := make([]string, 0)
+	for _, diag := range diags {
+		if diag.Severity == tfplugin5.Diagnostic_ERROR {
+			summaries = append(summaries, diag.Summary)
+		}
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(summaries, "; "))
+}
+
+func diagnosticsErr6(diags []*tfplugin6.Diagnostic) error {
+	summaries := make([]string, 0)
+	for _, diag := range diags {
+		if diag.Severity == tfplugin6.Diagnostic_ERROR {
+			summaries = append(summaries, diag.Summary)
+		}
+	}
+	if len(summaries) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(summaries, "; "))
+}
+
+// grpcProvider5 is the client side of a provider plugin
+// speaking the protocol version 5
+type grpcProvider5 struct {
+	plugin.NetRPCUnsupportedPlugin
+}
+
+func (p *grpcProvider5) GRPCServer(*plugin.GRPCBroker, *grpc.Server) error {
+	return errors.New("serving providers is not supported")
+}
+
+func (p *grpcProvider5) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return tfplugin5.NewProviderClient(conn), nil
+}
+
+// grpcProvider6 is the client side of a provider plugin
+// speaking the protocol version 6
+type grpcProvider6 struct {
+	plugin.NetRPCUnsupportedPlugin
+}
+
+func (p *grpcProvider6) GRPCServer(*plugin.GRPCBroker, *grpc.Server) error {
+	return errors.New("serving providers is not supported")
+}
+
+func (p *grpcProvider6) GRPCClient(_ context.Context, _ *plugin.GRPCBroker, conn *grpc.ClientConn) (interface{}, error) {
+	return tfplugin6.NewProviderClient(conn), nil
+}
@@ -0,0 +1,450 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerplugin
+
+import (
+	"fmt"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/opentofu/opentofu-schema/internal/tfplugin5"
+	"github.com/opentofu/opentofu-schema/internal/tfplugin6"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// The protocol responses are converted into the JSON representation
+// of provider schemas, so that the same conversion to hcl-lang schema
+// applies to both (see schema.ProviderSchemaFromJson).
+
+func providerSchemaFromProto5(resp *tfplugin5.GetProviderSchema_Response) (*tfjson.ProviderSchema, error) {
+	ps := &tfjson.ProviderSchema{
+		ResourceSchemas:          make(map[string]*tfjson.Schema, len(resp.ResourceSchemas)),
+		DataSourceSchemas:        make(map[string]*tfjson.Schema, len(resp.DataSourceSchemas)),
+		EphemeralResourceSchemas: make(map[string]*tfjson.Schema, len(resp.EphemeralResourceSchemas)),
+		Functions:                make(map[string]*tfjson.FunctionSignature, len(resp.Functions)),
+	}
+
+	var err error
+	if resp.Provider != nil {
+		ps.ConfigSchema, err = schemaFromProto5(resp.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("provider: %w", err)
+		}
+	}
+	for name, s := range resp.ResourceSchemas {
+		ps.ResourceSchemas[name], err = schemaFromProto5(s)
+		if err != nil {
+			return nil, fmt.Errorf("resource %q: %w", name, err)
+		}
+	}
+	for name, s := range resp.DataSourceSchemas {
+		ps.DataSourceSchemas[name], err = schemaFromProto5(s)
+		if err != nil {
+			return nil, fmt.Errorf("data source %q: %w", name, err)
+		}
+	}
+	for name, s := range resp.EphemeralResourceSchemas {
+		ps.EphemeralResourceSchemas[name], err = schemaFromProto5(s)
+		if err != nil {
+			return nil, fmt.Errorf("ephemeral resource %q: %w", name, err)
+		}
+	}
+	for name, fn := range resp.Functions {
+		ps.Functions[name], err = functionFromProto5(fn)
+		if err != nil {
+			return nil, fmt.Errorf("function %q: %w", name, err)
+		}
+	}
+
+	return ps, nil
+}
+
+func schemaFromProto5(s *tfplugin5.Schema) (*tfjson.Schema, error) {
+	block, err := blockFromProto5(s.Block)
+	if err != nil {
+		return nil, err
+	}
+	return &tfjson.Schema{
+		Version: uint64(s.Version),
+		Block:   block,
+	}, nil
+}
+
+func blockFromProto5(b *tfplugin5.Schema_Block) (*tfjson.SchemaBlock, error) {
+	if b == nil {
+		return &tfjson.SchemaBlock{}, nil
+	}
+
+	block := &tfjson.SchemaBlock{
+		Attributes:      make(map[string]*tfjson.SchemaAttribute, len(b.Attributes)),
+		NestedBlocks:    make(map[string]*tfjson.SchemaBlockType, len(b.BlockTypes)),
+		Description:     descriptionWithDeprecation(b.Description, b.DeprecationMessage),
+		DescriptionKind: descriptionKindFromProto5(b.DescriptionKind),
+		Deprecated:      b.Deprecated || b.DeprecationMessage != "",
+	}
+
+	for _, attr := range b.Attributes {
+		attrType, err := typeFromProto(attr.Type)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", attr.Name, err)
+		}
+		block.Attributes[attr.Name] = &tfjson.SchemaAttribute{
+			AttributeType:   attrType,
+			Description:     descriptionWithDeprecation(attr.Description, attr.DeprecationMessage),
+			DescriptionKind: descriptionKindFromProto5(attr.DescriptionKind),
+			Deprecated:      attr.Deprecated || attr.DeprecationMessage != "",
+			Required:        attr.Required,
+			Optional:        attr.Optional,
+			Computed:        attr.Computed,
+			Sensitive:       attr.Sensitive,
+			WriteOnly:       attr.WriteOnly,
+		}
+	}
+
+	for _, nb := range b.BlockTypes {
+		nestedBlock, err := blockFromProto5(nb.Block)
+		if err != nil {
+			return nil, fmt.Errorf("block %q: %w", nb.TypeName, err)
+		}
+		block.NestedBlocks[nb.TypeName] = &tfjson.SchemaBlockType{
+			NestingMode: blockNestingModeFromProto5(nb.Nesting),
+			Block:       nestedBlock,
+			MinItems:    uint64(nb.MinItems),
+			MaxItems:    uint64(nb.MaxItems),
+		}
+	}
+
+	return block, nil
+}
+
+func blockNestingModeFromProto5(mode tfplugin5.Schema_NestedBlock_NestingMode) tfjson.SchemaNestingMode {
+	switch mode {
+	case tfplugin5.Schema_NestedBlock_SINGLE:
+		return tfjson.SchemaNestingModeSingle
+	case tfplugin5.Schema_NestedBlock_GROUP:
+		return tfjson.SchemaNestingModeGroup
+	case tfplugin5.Schema_NestedBlock_LIST:
+		return tfjson.SchemaNestingModeList
+	case tfplugin5.Schema_NestedBlock_SET:
+		return tfjson.SchemaNestingModeSet
+	case tfplugin5.Schema_NestedBlock_MAP:
+		return tfjson.SchemaNestingModeMap
+	}
+	return ""
+}
+
+func descriptionKindFromProto5(kind tfplugin5.StringKind) tfjson.SchemaDescriptionKind {
+	if kind == tfplugin5.StringKind_MARKDOWN {
+		return tfjson.SchemaDescriptionKindMarkdown
+	}
+	return tfjson.SchemaDescriptionKindPlain
+}
+
+func functionFromProto5(fn *tfplugin5.Function) (*tfjson.FunctionSignature, error) {
+	sig := &tfjson.FunctionSignature{
+		Description:        functionDescription(fn.Description, descriptionKindFromProto5(fn.DescriptionKind)),
+		Summary:            fn.Summary,
+		DeprecationMessage: fn.DeprecationMessage,
+		ReturnType:         cty.DynamicPseudoType,
+	}
+
+	if fn.Return != nil {
+		returnType, err := typeFromProto(fn.Return.Type)
+		if err != nil {
+			return nil, fmt.Errorf("return type: %w", err)
+		}
+		sig.ReturnType = returnType
+	}
+
+	if len(fn.Parameters) > 0 {
+		sig.Parameters = make([]*tfjson.FunctionParameter, 0, len(fn.Parameters))
+		for _, param := range fn.Parameters {
+			p, err := functionParameterFromProto5(param)
+			if err != nil {
+				return nil, err
+			}
+			sig.Parameters = append(sig.Parameters, p)
+		}
+	}
+
+	if fn.VariadicParameter != nil {
+		p, err := functionParameterFromProto5(fn.VariadicParameter)
+		if err != nil {
+			return nil, err
+		}
+		sig.VariadicParameter = p
+	}
+
+	return sig, nil
+}
+
+func functionParameterFromProto5(param *tfplugin5.Function_Parameter) (*tfjson.FunctionParameter, error) {
+	paramType, err := typeFromProto(param.Type)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", param.Name, err)
+	}
+	return &tfjson.FunctionParameter{
+		Name:        param.Name,
+		Description: functionDescription(param.Description, descriptionKindFromProto5(param.DescriptionKind)),
+		IsNullable:  param.AllowNullValue,
+		Type:        paramType,
+	}, nil
+}
+
+func providerSchemaFromProto6(resp *tfplugin6.GetProviderSchema_Response) (*tfjson.ProviderSchema, error) {
+	ps := &tfjson.ProviderSchema{
+		ResourceSchemas:          make(map[string]*tfjson.Schema, len(resp.ResourceSchemas)),
+		DataSourceSchemas:        make(map[string]*tfjson.Schema, len(resp.DataSourceSchemas)),
+		EphemeralResourceSchemas: make(map[string]*tfjson.Schema, len(resp.EphemeralResourceSchemas)),
+		Functions:                make(map[string]*tfjson.FunctionSignature, len(resp.Functions)),
+	}
+
+	var err error
+	if resp.Provider != nil {
+		ps.ConfigSchema, err = schemaFromProto6(resp.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("provider: %w", err)
+		}
+	}
+	for name, s := range resp.ResourceSchemas {
+		ps.ResourceSchemas[name], err = schemaFromProto6(s)
+		if err != nil {
+			return nil, fmt.Errorf("resource %q: %w", name, err)
+		}
+	}
+	for name, s := range resp.DataSourceSchemas {
+		ps.DataSourceSchemas[name], err = schemaFromProto6(s)
+		if err != nil {
+			return nil, fmt.Errorf("data source %q: %w", name, err)
+		}
+	}
+	for name, s := range resp.EphemeralResourceSchemas {
+		ps.EphemeralResourceSchemas[name], err = schemaFromProto6(s)
+		if err != nil {
+			return nil, fmt.Errorf("ephemeral resource %q: %w", name, err)
+		}
+	}
+	for name, fn := range resp.Functions {
+		ps.Functions[name], err = functionFromProto6(fn)
+		if err != nil {
+			return nil, fmt.Errorf("function %q: %w", name, err)
+		}
+	}
+
+	return ps, nil
+}
+
+func schemaFromProto6(s *tfplugin6.Schema) (*tfjson.Schema, error) {
+	block, err := blockFromProto6(s.Block)
+	if err != nil {
+		return nil, err
+	}
+	return &tfjson.Schema{
+		Version: uint64(s.Version),
+		Block:   block,
+	}, nil
+}
+
+func blockFromProto6(b *tfplugin6.Schema_Block) (*tfjson.SchemaBlock, error) {
+	if b == nil {
+		return &tfjson.SchemaBlock{}, nil
+	}
+
+	attributes, err := attributesFromProto6(b.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &tfjson.SchemaBlock{
+		Attributes:      attributes,
+		NestedBlocks:    make(map[string]*tfjson.SchemaBlockType, len(b.BlockTypes)),
+		Description:     descriptionWithDeprecation(b.Description, b.DeprecationMessage),
+		DescriptionKind: descriptionKindFromProto6(b.DescriptionKind),
+		Deprecated:      b.Deprecated || b.DeprecationMessage != "",
+	}
+
+	for _, nb := range b.BlockTypes {
+		nestedBlock, err := blockFromProto6(nb.Block)
+		if err != nil {
+			return nil, fmt.Errorf("block %q: %w", nb.TypeName, err)
+		}
+		block.NestedBlocks[nb.TypeName] = &tfjson.SchemaBlockType{
+			NestingMode: blockNestingModeFromProto6(nb.Nesting),
+			Block:       nestedBlock,
+			MinItems:    uint64(nb.MinItems),
+			MaxItems:    uint64(nb.MaxItems),
+		}
+	}
+
+	return block, nil
+}
+
+func attributesFromProto6(attrs []*tfplugin6.Schema_Attribute) (map[string]*tfjson.SchemaAttribute, error) {
+	attributes := make(map[string]*tfjson.SchemaAttribute, len(attrs))
+	for _, attr := range attrs {
+		attribute := &tfjson.SchemaAttribute{
+			Description:     descriptionWithDeprecation(attr.Description, attr.DeprecationMessage),
+			DescriptionKind: descriptionKindFromProto6(attr.DescriptionKind),
+			Deprecated:      attr.Deprecated || attr.DeprecationMessage != "",
+			Required:        attr.Required,
+			Optional:        attr.Optional,
+			Computed:        attr.Computed,
+			Sensitive:       attr.Sensitive,
+			WriteOnly:       attr.WriteOnly,
+		}
+
+		if attr.NestedType != nil {
+			nestedAttributes, err := attributesFromProto6(attr.NestedType.Attributes)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", attr.Name, err)
+			}
+			attribute.AttributeNestedType = &tfjson.SchemaNestedAttributeType{
+				Attributes:  nestedAttributes,
+				NestingMode: objectNestingModeFromProto6(attr.NestedType.Nesting),
+			}
+		} else {
+			attrType, err := typeFromProto(attr.Type)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", attr.Name, err)
+			}
+			attribute.AttributeType = attrType
+		}
+
+		attributes[attr.Name] = attribute
+	}
+	return attributes, nil
+}
+
+func blockNestingModeFromProto6(mode tfplugin6.Schema_NestedBlock_NestingMode) tfjson.SchemaNestingMode {
+	switch mode {
+	case tfplugin6.Schema_NestedBlock_SINGLE:
+		return tfjson.SchemaNestingModeSingle
+	case tfplugin6.Schema_NestedBlock_GROUP:
+		return tfjson.SchemaNestingModeGroup
+	case tfplugin6.Schema_NestedBlock_LIST:
+		return tfjson.SchemaNestingModeList
+	case tfplugin6.Schema_NestedBlock_SET:
+		return tfjson.SchemaNestingModeSet
+	case tfplugin6.Schema_NestedBlock_MAP:
+		return tfjson.SchemaNestingModeMap
+	}
+	return ""
+}
+
+func objectNestingModeFromProto6(mode tfplugin6.Schema_Object_NestingMode) tfjson.SchemaNestingMode {
+	switch mode {
+	case tfplugin6.Schema_Object_SINGLE:
+		return tfjson.SchemaNestingModeSingle
+	case tfplugin6.Schema_Object_LIST:
+		return tfjson.SchemaNestingModeList
+	case tfplugin6.Schema_Object_SET:
+		return tfjson.SchemaNestingModeSet
+	case tfplugin6.Schema_Object_MAP:
+		return tfjson.SchemaNestingModeMap
+	}
+	return ""
+}
+
+func descriptionKindFromProto6(kind tfplugin6.StringKind) tfjson.SchemaDescriptionKind {
+	if kind == tfplugin6.StringKind_MARKDOWN {
+		return tfjson.SchemaDescriptionKindMarkdown
+	}
+	return tfjson.SchemaDescriptionKindPlain
+}
+
+func functionFromProto6(fn *tfplugin6.Function) (*tfjson.FunctionSignature, error) {
+	sig := &tfjson.FunctionSignature{
+		Description:        functionDescription(fn.Description, descriptionKindFromProto6(fn.DescriptionKind)),
+		Summary:            fn.Summary,
+		DeprecationMessage: fn.DeprecationMessage,
+		ReturnType:         cty.DynamicPseudoType,
+	}
+
+	if fn.Return != nil {
+		returnType, err := typeFromProto(fn.Return.Type)
+		if err != nil {
+			return nil, fmt.Errorf("return type: %w", err)
+		}
+		sig.ReturnType = returnType
+	}
+
+	if len(fn.Parameters) > 0 {
+		sig.Parameters = make([]*tfjson.FunctionParameter, 0, len(fn.Parameters))
+		for _, param := range fn.Parameters {
+			p, err := functionParameterFromProto6(param)
+			if err != nil {
+				return nil, err
+			}
+			sig.Parameters = append(sig.Parameters, p)
+		}
+	}
+
+	if fn.VariadicParameter != nil {
+		p, err := functionParameterFromProto6(fn.VariadicParameter)
+		if err != nil {
+			return nil, err
+		}
+		sig.VariadicParameter = p
+	}
+
+	return sig, nil
+}
+
+func functionParameterFromProto6(param *tfplugin6.Function_Parameter) (*tfjson.FunctionParameter, error) {
+	paramType, err := typeFromProto(param.Type)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", param.Name, err)
+	}
+	return &tfjson.FunctionParameter{
+		Name:        param.Name,
+		Description: functionDescription(param.Description, descriptionKindFromProto6(param.DescriptionKind)),
+		IsNullable:  param.AllowNullValue,
+		Type:        paramType,
+	}, nil
+}
+
+// descriptionWithDeprecation appends the deprecation message to the
+// description, since the JSON representation only records whether
+// a block or attribute is deprecated
+func descriptionWithDeprecation(description string, deprecationMessage string) string {
+	if deprecationMessage == "" {
+		return description
+	}
+	if description == "" {
+		return "Deprecated: " + deprecationMessage
+	}
+	return description + "\n\nDeprecated: " + deprecationMessage
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+)
+
+// functionDescription returns the description of a function or its
+// parameter as Markdown, which is how function descriptions are rendered
+// as the JSON representation doesn't record their kind
+func functionDescription(description string, kind tfjson.SchemaDescriptionKind) string {
+	if kind == tfjson.SchemaDescriptionKindMarkdown {
+		return description
+	}
+	return markdownEscaper.Replace(description)
+}
+
+// typeFromProto decodes a JSON-encoded type constraint
+func typeFromProto(b []byte) (cty.Type, error) {
+	if len(b) == 0 {
+		return cty.DynamicPseudoType, nil
+	}
+	return ctyjson.UnmarshalType(b)
+}
@@ -0,0 +1,127 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerplugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/opentofu/opentofu-schema/schema"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+// Loader loads provider schemas from plugin binaries in a local plugin
+// cache directory, e.g. the one configured via plugin_cache_dir.
+//
+// The cache is expected to use the unpacked layout:
+// <hostname>/<namespace>/<type>/<version>/<os>_<arch>/terraform-provider-<type>*
+type Loader struct {
+	cacheDir string
+	platform string
+}
+
+// NewLoader returns a loader of provider binaries
+// for the current platform from the given cache directory
+func NewLoader(cacheDir string) *Loader {
+	return &Loader{
+		cacheDir: cacheDir,
+		platform: runtime.GOOS + "_" + runtime.GOARCH,
+	}
+}
+
+// ProviderBinaryPath returns the path of the binary of the newest cached
+// version of the given provider matching the given version constraints
+func (l *Loader) ProviderBinaryPath(pAddr tfaddr.Provider, vc version.Constraints) (string, *version.Version, error) {
+	providerDir := filepath.Join(l.cacheDir, pAddr.Hostname.ForDisplay(), pAddr.Namespace, pAddr.Type)
+	entries, err := os.ReadDir(providerDir)
+	if err != nil {
+		return "", nil, ProviderNotFoundErr{Addr: pAddr, Constraints: vc}
+	}
+
+	versions := make(version.Collection, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		v, err := version.NewVersion(entry.Name())
+		if err != nil {
+			continue
+		}
+		if len(vc) > 0 && !vc.Check(v) {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.Sort(sort.Reverse(versions))
+
+	for _, v := range versions {
+		// The original directory name is used, since
+		// versions are not always written in canonical form
+		binaryPath, ok := findProviderBinary(filepath.Join(providerDir, v.Original(), l.platform), pAddr.Type)
+		if ok {
+			return binaryPath, v, nil
+		}
+	}
+
+	return "", nil, ProviderNotFoundErr{Addr: pAddr, Constraints: vc}
+}
+
+// ProviderSchema loads the schema of the newest cached version of the
+// given provider matching the given version constraints
+func (l *Loader) ProviderSchema(ctx context.Context, pAddr tfaddr.Provider, vc version.Constraints) (*schema.ProviderSchema, error) {
+	binaryPath, v, err := l.ProviderBinaryPath(pAddr, vc)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := LoadProviderSchema(ctx, binaryPath, pAddr)
+	if err != nil {
+		return nil, err
+	}
+	ps.SetProviderVersion(pAddr, v)
+
+	return ps, nil
+}
+
+func findProviderBinary(dir string, providerType string) (string, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", false
+	}
+
+	prefix := "terraform-provider-" + providerType
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		// The binary is either named without a suffix,
+		// or with a version suffix, e.g. _v1.2.3
+		if rest := name[len(prefix):]; rest != "" && !strings.HasPrefix(rest, "_") && rest != ".exe" {
+			continue
+		}
+		return filepath.Join(dir, name), true
+	}
+	return "", false
+}
+
+type ProviderNotFoundErr struct {
+	Addr        tfaddr.Provider
+	Constraints version.Constraints
+}
+
+func (e ProviderNotFoundErr) Error() string {
+	if len(e.Constraints) > 0 {
+		return fmt.Sprintf("provider %s matching %s not found in plugin cache", e.Addr, e.Constraints)
+	}
+	return fmt.Sprintf("provider %s not found in plugin cache", e.Addr)
+}
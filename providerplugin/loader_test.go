@@ -0,0 +1,157 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerplugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/addr"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestLoader_ProviderBinaryPath(t *testing.T) {
+	cacheDir := t.TempDir()
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+	writeCachedProvider(t, cacheDir, "1.0.0", platform, "terraform-provider-stub_v1.0.0")
+	writeCachedProvider(t, cacheDir, "1.1.0", platform, "terraform-provider-stub_v1.1.0")
+	writeCachedProvider(t, cacheDir, "2.0.0", platform, "terraform-provider-stub")
+	writeCachedProvider(t, cacheDir, "2.1.0", platform, "terraform-provider-stubby")
+	// Not available for the current platform
+	writeCachedProvider(t, cacheDir, "3.0.0", "plan9_386", "terraform-provider-stub")
+
+	loader := NewLoader(cacheDir)
+	pAddr := addr.NewDefaultProvider("stub")
+
+	testCases := []struct {
+		constraint      string
+		expectedVersion string
+	}{
+		{"", "2.0.0"},
+		{"~> 1.0", "1.1.0"},
+		{"1.0.0", "1.0.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			var vc version.Constraints
+			if tc.constraint != "" {
+				vc = version.MustConstraints(version.NewConstraint(tc.constraint))
+			}
+			_, v, err := loader.ProviderBinaryPath(pAddr, vc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v.String() != tc.expectedVersion {
+				t.Fatalf("expected version %s, given %s", tc.expectedVersion, v)
+			}
+		})
+	}
+
+	_, _, err := loader.ProviderBinaryPath(pAddr, version.MustConstraints(version.NewConstraint(">= 3.0")))
+	var notFoundErr ProviderNotFoundErr
+	if !errors.As(err, &notFoundErr) {
+		t.Fatalf("expected ProviderNotFoundErr, given %#v", err)
+	}
+}
+
+func TestLoader_ProviderSchema(t *testing.T) {
+	if testing.Short() {
+		t.Skip("launches provider plugins")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, protocol := range []string{"5", "6"} {
+		t.Run("protocol "+protocol, func(t *testing.T) {
+			t.Setenv(stubProviderEnvVar, protocol)
+
+			// The test binary itself serves as the provider (see TestMain)
+			cacheDir := t.TempDir()
+			dir := cachedProviderDir(t, cacheDir, "1.0.0", runtime.GOOS+"_"+runtime.GOARCH)
+			if err := os.Symlink(executable, filepath.Join(dir, "terraform-provider-stub_v1.0.0")); err != nil {
+				t.Skipf("unable to link provider binary: %s", err)
+			}
+
+			ps, err := NewLoader(cacheDir).ProviderSchema(context.Background(), addr.NewDefaultProvider("stub"), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ps.Provider.Detail != "hashicorp/stub 1.0.0" {
+				t.Fatalf("expected provider detail with version, given %q", ps.Provider.Detail)
+			}
+
+			region, ok := ps.Provider.Attributes["region"]
+			if !ok || !region.IsRequired {
+				t.Fatalf("expected required region attribute, given %#v", ps.Provider.Attributes)
+			}
+
+			resource, ok := ps.Resources["stub_instance"]
+			if !ok {
+				t.Fatal("expected stub_instance resource schema")
+			}
+			if _, ok := resource.Blocks["network"]; !ok {
+				t.Fatal("expected network block of stub_instance")
+			}
+			legacyID := resource.Attributes["legacy_id"]
+			if legacyID == nil || !legacyID.IsDeprecated || legacyID.Description.Value != "Deprecated: Use id instead." {
+				t.Fatalf("expected deprecated legacy_id attribute, given %#v", legacyID)
+			}
+			if protocol == "6" {
+				if _, ok := resource.Attributes["tags"].Constraint.(schema.Object); !ok {
+					t.Fatalf("expected nested object attribute, given %#v", resource.Attributes["tags"].Constraint)
+				}
+			}
+
+			if _, ok := ps.DataSources["stub_image"]; !ok {
+				t.Fatal("expected stub_image data source schema")
+			}
+			if _, ok := ps.EphemeralResources["stub_token"]; !ok {
+				t.Fatal("expected stub_token ephemeral resource schema")
+			}
+
+			fn, ok := ps.Functions["parse_id"]
+			if !ok {
+				t.Fatal("expected parse_id function signature")
+			}
+			if !fn.ReturnType.Equals(cty.String) || len(fn.Params) != 1 {
+				t.Fatalf("unexpected function signature: %#v", fn)
+			}
+			if fn.Description != "Parses an *instance* ID" {
+				t.Fatalf("unexpected function description: %q", fn.Description)
+			}
+			if fn.Params[0].Description != `ID of the form \<region\>\_\<name\>` {
+				t.Fatalf("expected plain parameter description to be escaped, given %q", fn.Params[0].Description)
+			}
+		})
+	}
+}
+
+// writeCachedProvider creates an empty provider binary in the plugin cache
+func writeCachedProvider(t *testing.T, cacheDir, v, platform, binaryName string) {
+	dir := cachedProviderDir(t, cacheDir, v, platform)
+	if err := os.WriteFile(filepath.Join(dir, binaryName), []byte{}, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func cachedProviderDir(t *testing.T, cacheDir, v, platform string) string {
+	dir := filepath.Join(cacheDir, "registry.opentofu.org", "hashicorp", "stub", v, platform)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
@@ -0,0 +1,216 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerplugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/opentofu/opentofu-schema/internal/tfplugin5"
+	"github.com/opentofu/opentofu-schema/internal/tfplugin6"
+	"google.golang.org/grpc"
+)
+
+// stubProviderEnvVar makes the test binary serve a stub provider
+// speaking the protocol version set in the variable, instead of
+// running tests
+const stubProviderEnvVar = "OPENTOFU_SCHEMA_STUB_PROVIDER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(stubProviderEnvVar) {
+	case "5":
+		serveStubProvider(5, &stubProvider5{})
+	case "6":
+		serveStubProvider(6, &stubProvider6{})
+	default:
+		os.Exit(m.Run())
+	}
+}
+
+func serveStubProvider(protocolVersion int, p plugin.Plugin) {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: Handshake,
+		VersionedPlugins: map[int]plugin.PluginSet{
+			protocolVersion: {providerPluginName: p},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+	})
+}
+
+type stubProvider5 struct {
+	plugin.NetRPCUnsupportedPlugin
+}
+
+func (p *stubProvider5) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	tfplugin5.RegisterProviderServer(s, &stubProviderServer5{})
+	return nil
+}
+
+func (p *stubProvider5) GRPCClient(context.Context, *plugin.GRPCBroker, *grpc.ClientConn) (interface{}, error) {
+	return nil, errors.New("stub provider has no client")
+}
+
+type stubProviderServer5 struct {
+	tfplugin5.UnimplementedProviderServer
+}
+
+func (s *stubProviderServer5) GetSchema(context.Context, *tfplugin5.GetProviderSchema_Request) (*tfplugin5.GetProviderSchema_Response, error) {
+	return &tfplugin5.GetProviderSchema_Response{
+		Provider: &tfplugin5.Schema{
+			Block: &tfplugin5.Schema_Block{
+				Attributes: []*tfplugin5.Schema_Attribute{
+					{Name: "region", Type: []byte(`"string"`), Required: true},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfplugin5.Schema{
+			"stub_instance": {
+				Version: 1,
+				Block: &tfplugin5.Schema_Block{
+					Attributes: []*tfplugin5.Schema_Attribute{
+						{Name: "name", Type: []byte(`"string"`), Required: true},
+						{Name: "id", Type: []byte(`"string"`), Computed: true},
+						{Name: "legacy_id", Type: []byte(`"string"`), Computed: true, DeprecationMessage: "Use id instead."},
+					},
+					BlockTypes: []*tfplugin5.Schema_NestedBlock{
+						{
+							TypeName: "network",
+							Nesting:  tfplugin5.Schema_NestedBlock_LIST,
+							Block: &tfplugin5.Schema_Block{
+								Attributes: []*tfplugin5.Schema_Attribute{
+									{Name: "subnet_id", Type: []byte(`"string"`), Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfplugin5.Schema{
+			"stub_image": {
+				Block: &tfplugin5.Schema_Block{
+					Attributes: []*tfplugin5.Schema_Attribute{
+						{Name: "id", Type: []byte(`"string"`), Computed: true},
+					},
+				},
+			},
+		},
+		EphemeralResourceSchemas: map[string]*tfplugin5.Schema{
+			"stub_token": {
+				Block: &tfplugin5.Schema_Block{
+					Attributes: []*tfplugin5.Schema_Attribute{
+						{Name: "value", Type: []byte(`"string"`), Computed: true, Sensitive: true},
+					},
+				},
+			},
+		},
+		Functions: map[string]*tfplugin5.Function{
+			"parse_id": {
+				Parameters: []*tfplugin5.Function_Parameter{
+					{Name: "id", Type: []byte(`"string"`), Description: "ID of the form <region>_<name>"},
+				},
+				Return:          &tfplugin5.Function_Return{Type: []byte(`"string"`)},
+				Summary:         "Parses an instance ID",
+				Description:     "Parses an *instance* ID",
+				DescriptionKind: tfplugin5.StringKind_MARKDOWN,
+			},
+		},
+	}, nil
+}
+
+type stubProvider6 struct {
+	plugin.NetRPCUnsupportedPlugin
+}
+
+func (p *stubProvider6) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	tfplugin6.RegisterProviderServer(s, &stubProviderServer6{})
+	return nil
+}
+
+func (p *stubProvider6) GRPCClient(context.Context, *plugin.GRPCBroker, *grpc.ClientConn) (interface{}, error) {
+	return nil, errors.New("stub provider has no client")
+}
+
+type stubProviderServer6 struct {
+	tfplugin6.UnimplementedProviderServer
+}
+
+func (s *stubProviderServer6) GetProviderSchema(context.Context, *tfplugin6.GetProviderSchema_Request) (*tfplugin6.GetProviderSchema_Response, error) {
+	return &tfplugin6.GetProviderSchema_Response{
+		Provider: &tfplugin6.Schema{
+			Block: &tfplugin6.Schema_Block{
+				Attributes: []*tfplugin6.Schema_Attribute{
+					{Name: "region", Type: []byte(`"string"`), Required: true},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfplugin6.Schema{
+			"stub_instance": {
+				Version: 1,
+				Block: &tfplugin6.Schema_Block{
+					Attributes: []*tfplugin6.Schema_Attribute{
+						{Name: "name", Type: []byte(`"string"`), Required: true},
+						{Name: "id", Type: []byte(`"string"`), Computed: true},
+						{Name: "legacy_id", Type: []byte(`"string"`), Computed: true, DeprecationMessage: "Use id instead."},
+						{
+							Name:     "tags",
+							Optional: true,
+							NestedType: &tfplugin6.Schema_Object{
+								Nesting: tfplugin6.Schema_Object_SINGLE,
+								Attributes: []*tfplugin6.Schema_Attribute{
+									{Name: "owner", Type: []byte(`"string"`), Optional: true},
+								},
+							},
+						},
+					},
+					BlockTypes: []*tfplugin6.Schema_NestedBlock{
+						{
+							TypeName: "network",
+							Nesting:  tfplugin6.Schema_NestedBlock_LIST,
+							Block: &tfplugin6.Schema_Block{
+								Attributes: []*tfplugin6.Schema_Attribute{
+									{Name: "subnet_id", Type: []byte(`"string"`), Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfplugin6.Schema{
+			"stub_image": {
+				Block: &tfplugin6.Schema_Block{
+					Attributes: []*tfplugin6.Schema_Attribute{
+						{Name: "id", Type: []byte(`"string"`), Computed: true},
+					},
+				},
+			},
+		},
+		EphemeralResourceSchemas: map[string]*tfplugin6.Schema{
+			"stub_token": {
+				Block: &tfplugin6.Schema_Block{
+					Attributes: []*tfplugin6.Schema_Attribute{
+						{Name: "value", Type: []byte(`"string"`), Computed: true, Sensitive: true},
+					},
+				},
+			},
+		},
+		Functions: map[string]*tfplugin6.Function{
+			"parse_id": {
+				Parameters: []*tfplugin6.Function_Parameter{
+					{Name: "id", Type: []byte(`"string"`), Description: "ID of the form <region>_<name>"},
+				},
+				Return:          &tfplugin6.Function_Return{Type: []byte(`"string"`)},
+				Summary:         "Parses an instance ID",
+				Description:     "Parses an *instance* ID",
+				DescriptionKind: tfplugin6.StringKind_MARKDOWN,
+			},
+		},
+	}, nil
+}
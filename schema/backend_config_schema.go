@@ -0,0 +1,29 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/opentofu/opentofu-schema/internal/schema/backends"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+// SchemaForBackendConfigFile returns the schema of a partial backend
+// configuration file (*.tfbackend), as passed to `tofu init -backend-config`,
+// based on the type of the backend declared in the given module.
+func SchemaForBackendConfigFile(meta *module.Meta, v *version.Version) (*schema.BodySchema, error) {
+	if meta == nil || meta.Backend == nil {
+		return nil, backendRequiredErr{}
+	}
+
+	bs, ok := backends.ConfigFileBodySchema(meta.Backend.Type, v)
+	if !ok {
+		return nil, UnknownBackendTypeErr{Type: meta.Backend.Type}
+	}
+
+	return bs, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/opentofu/opentofu-schema/backend"
+	"github.com/opentofu/opentofu-schema/module"
+)
+
+func TestSchemaForBackendConfigFile(t *testing.T) {
+	v := version.Must(version.NewVersion("1.10.0"))
+	meta := &module.Meta{
+		Backend: &module.Backend{
+			Type: "s3",
+			Data: &backend.S3{},
+		},
+	}
+
+	bs, err := SchemaForBackendConfigFile(meta, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, ok := bs.Attributes["bucket"]
+	if !ok {
+		t.Fatal("expected bucket attribute in s3 backend config schema")
+	}
+	if bucket.IsRequired || !bucket.IsOptional {
+		t.Fatal("expected bucket attribute to be optional in backend config file")
+	}
+}
+
+func TestSchemaForBackendConfigFile_errors(t *testing.T) {
+	v := version.Must(version.NewVersion("1.10.0"))
+
+	_, err := SchemaForBackendConfigFile(&module.Meta{}, v)
+	if err == nil {
+		t.Fatal("expected error for module without backend")
+	}
+
+	_, err = SchemaForBackendConfigFile(&module.Meta{
+		Backend: &module.Backend{
+			Type: "unknown",
+			Data: &backend.UnknownBackendData{},
+		},
+	}, v)
+	var typeErr UnknownBackendTypeErr
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("expected UnknownBackendTypeErr, got %#v", err)
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/go-version"
+)
+
+// DiffCoreModuleSchemas compares the core module schemas of two
+// OpenTofu versions and returns all changes of blocks, attributes,
+// dependent bodies (e.g. backends and provisioners) and their
+// constraints made between the two versions.
+// It will return error if either schema cannot be found.
+func DiffCoreModuleSchemas(oldVersion, newVersion *version.Version) (*SchemaDiff, error) {
+	oldSchema, err := CoreModuleSchemaForVersion(oldVersion)
+	if err != nil {
+		return nil, err
+	}
+	newSchema, err := CoreModuleSchemaForVersion(newVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	sd := &schemaDiffer{}
+	sd.diffBody([]string{}, oldSchema, newSchema)
+
+	return sd.diff(), nil
+}
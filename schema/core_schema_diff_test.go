@@ -0,0 +1,116 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+)
+
+func TestDiffCoreModuleSchemas(t *testing.T) {
+	diff, err := DiffCoreModuleSchemas(version.Must(version.NewVersion("1.11.0")), version.Must(version.NewVersion("1.12.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChanges := []SchemaChange{
+		{
+			Kind:    SchemaChangeAdded,
+			Element: SchemaElementBlock,
+			Path:    []string{"language"},
+		},
+		{
+			Kind:    SchemaChangeAdded,
+			Element: SchemaElementAttribute,
+			Path:    []string{"resource", "lifecycle", "destroy"},
+		},
+	}
+	if diff := cmp.Diff(expectedChanges, diff.Changes); diff != "" {
+		t.Fatalf("unexpected changes: %s", diff)
+	}
+}
+
+func TestDiffCoreModuleSchemas_widenedConstraints(t *testing.T) {
+	diff, err := DiffCoreModuleSchemas(version.Must(version.NewVersion("1.10.0")), version.Must(version.NewVersion("1.11.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// depends_on accepts references to ephemeral resources since 1.11
+	var dependsOnChanged, ephemeralAdded bool
+	for _, c := range diff.Changes {
+		if c.Kind == SchemaChangeTypeChanged && cmp.Equal(c.Path, []string{"resource", "depends_on"}) {
+			dependsOnChanged = true
+		}
+		if c.Kind == SchemaChangeAdded && cmp.Equal(c.Path, []string{"ephemeral"}) {
+			ephemeralAdded = true
+		}
+	}
+	if !dependsOnChanged {
+		t.Fatalf("expected changed resource.depends_on, given %#v", diff.Changes)
+	}
+	if !ephemeralAdded {
+		t.Fatalf("expected added ephemeral block, given %#v", diff.Changes)
+	}
+	if diff.HasBreakingChanges() {
+		t.Fatalf("expected no breaking changes, given:\n%s", diff.Markdown())
+	}
+}
+
+func TestDiffCoreModuleSchemas_dependentBodies(t *testing.T) {
+	diff, err := DiffCoreModuleSchemas(version.Must(version.NewVersion("0.12.0")), version.Must(version.NewVersion("0.13.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedChanges := []SchemaChange{
+		{
+			Kind:    SchemaChangeAdded,
+			Element: SchemaElementDependentBody,
+			Path:    []string{"terraform", "backend", "cos"},
+		},
+		{
+			Kind:     SchemaChangeRemoved,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"terraform", "backend", "s3", "lock_table"},
+			Breaking: true,
+		},
+	}
+	for _, expected := range expectedChanges {
+		found := false
+		for _, c := range diff.Changes {
+			if cmp.Equal(expected, c) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected change %s", expected)
+		}
+	}
+}
+
+func TestDiffCoreModuleSchemas_sameVersion(t *testing.T) {
+	v := version.Must(version.NewVersion("1.9.0"))
+	diff, err := DiffCoreModuleSchemas(v, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Changes) != 0 {
+		t.Fatalf("expected no changes, given:\n%s", diff.Markdown())
+	}
+}
+
+func TestDiffCoreModuleSchemas_unknownVersion(t *testing.T) {
+	_, err := DiffCoreModuleSchemas(version.Must(version.NewVersion("0.11.0")), version.Must(version.NewVersion("1.0.0")))
+	var noSchemaErr NoCompatibleSchemaErr
+	if !errors.As(err, &noSchemaErr) {
+		t.Fatalf("expected NoCompatibleSchemaErr, given %#v", err)
+	}
+}
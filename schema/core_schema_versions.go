@@ -0,0 +1,195 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+)
+
+// VersionRange is the range of known OpenTofu versions
+// in which an element of the core schema is available
+type VersionRange struct {
+	First *version.Version
+	Last  *version.Version
+}
+
+// CoreSchemaElement is a block, attribute or dependent body
+// of the core module schema
+type CoreSchemaElement struct {
+	Kind SchemaElementKind
+	// Path is the path of the element, using the same
+	// form as SchemaChange, e.g. ["resource", "lifecycle", "destroy"]
+	Path     []string
+	Versions VersionRange
+}
+
+// CoreSchemaVersionIndex records the versions in which each
+// element of the core module schema is available
+type CoreSchemaVersionIndex struct {
+	elements map[string]*CoreSchemaElement
+	oldest   *version.Version
+}
+
+var (
+	coreSchemaVersionIndex     *CoreSchemaVersionIndex
+	coreSchemaVersionIndexOnce sync.Once
+)
+
+// CoreSchemaVersions returns the index of versions in which elements
+// of the core module schema are available, computed from schemas
+// of all known OpenTofu versions.
+//
+// Elements available in the oldest known version may have been
+// introduced earlier, so their first version is only a lower bound.
+func CoreSchemaVersions() *CoreSchemaVersionIndex {
+	coreSchemaVersionIndexOnce.Do(func() {
+		coreSchemaVersionIndex = newCoreSchemaVersionIndex(tofuVersions)
+	})
+	return coreSchemaVersionIndex
+}
+
+func newCoreSchemaVersionIndex(versions version.Collection) *CoreSchemaVersionIndex {
+	idx := &CoreSchemaVersionIndex{
+		elements: make(map[string]*CoreSchemaElement),
+	}
+
+	// Schemas only differ between core versions,
+	// so pre-releases are indexed as their final release
+	cores := make(version.Collection, 0, len(versions))
+	seen := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		core := v.Core()
+		if seen[core.String()] {
+			continue
+		}
+		seen[core.String()] = true
+		cores = append(cores, core)
+	}
+	sort.Sort(cores)
+
+	for _, v := range cores {
+		bs, err := CoreModuleSchemaForVersion(v)
+		if err != nil {
+			continue
+		}
+		if idx.oldest == nil {
+			idx.oldest = v
+		}
+
+		walkCoreSchema([]string{}, bs, func(kind SchemaElementKind, path []string, _ *lang.MarkupContent) {
+			key := elementKey(path)
+			elem, ok := idx.elements[key]
+			if !ok {
+				elem = &CoreSchemaElement{
+					Kind:     kind,
+					Path:     copyPath(path),
+					Versions: VersionRange{First: v},
+				}
+				idx.elements[key] = elem
+			}
+			elem.Versions.Last = v
+		})
+	}
+
+	return idx
+}
+
+// VersionRange returns the range of versions in which the element
+// of the given path (e.g. "resource", "lifecycle", "destroy") is available
+func (idx *CoreSchemaVersionIndex) VersionRange(path ...string) (VersionRange, bool) {
+	elem, ok := idx.elements[elementKey(path)]
+	if !ok {
+		return VersionRange{}, false
+	}
+	return elem.Versions, true
+}
+
+// Elements returns all indexed elements, sorted by path
+func (idx *CoreSchemaVersionIndex) Elements() []CoreSchemaElement {
+	elements := make([]CoreSchemaElement, 0, len(idx.elements))
+	for _, key := range sortedKeys(idx.elements) {
+		elem := *idx.elements[key]
+		elem.Path = copyPath(elem.Path)
+		elements = append(elements, elem)
+	}
+	return elements
+}
+
+// AnnotateDescriptions returns a copy of the given core module schema
+// with "Available since vX.Y" appended to descriptions of all blocks,
+// attributes and dependent bodies introduced after the oldest known version
+func (idx *CoreSchemaVersionIndex) AnnotateDescriptions(bs *schema.BodySchema) *schema.BodySchema {
+	bs = bs.Copy()
+
+	walkCoreSchema([]string{}, bs, func(_ SchemaElementKind, path []string, description *lang.MarkupContent) {
+		elem, ok := idx.elements[elementKey(path)]
+		if !ok || !elem.Versions.First.GreaterThan(idx.oldest) {
+			return
+		}
+		*description = appendToDescription(*description, availableSinceText(elem.Versions.First))
+	})
+
+	return bs
+}
+
+func availableSinceText(v *version.Version) string {
+	segments := v.Segments()
+	return fmt.Sprintf("Available since v%d.%d", segments[0], segments[1])
+}
+
+func appendToDescription(description lang.MarkupContent, text string) lang.MarkupContent {
+	if description.Value == "" {
+		return lang.PlainText(text)
+	}
+	description.Value += "\n\n" + text
+	return description
+}
+
+// walkCoreSchema calls the given function for every block, attribute
+// and dependent body of the given body, along with their description
+func walkCoreSchema(path []string, body *schema.BodySchema, fn func(SchemaElementKind, []string, *lang.MarkupContent)) {
+	if body == nil {
+		return
+	}
+
+	for _, name := range sortedKeys(body.Attributes) {
+		attr := body.Attributes[name]
+		if attr == nil {
+			continue
+		}
+		fn(SchemaElementAttribute, append(path, name), &attr.Description)
+	}
+
+	for _, name := range sortedKeys(body.Blocks) {
+		block := body.Blocks[name]
+		if block == nil {
+			continue
+		}
+		blockPath := append(path, name)
+		fn(SchemaElementBlock, blockPath, &block.Description)
+		walkCoreSchema(blockPath, block.Body, fn)
+
+		for key, depBody := range block.DependentBody {
+			if depBody == nil {
+				continue
+			}
+			depPath := append(blockPath, dependencyKeysName(key))
+			fn(SchemaElementDependentBody, depPath, &depBody.Description)
+			walkCoreSchema(depPath, depBody, fn)
+		}
+	}
+}
+
+func elementKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
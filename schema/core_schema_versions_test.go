@@ -0,0 +1,98 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestCoreSchemaVersions(t *testing.T) {
+	idx := CoreSchemaVersions()
+
+	testCases := []struct {
+		path          []string
+		expectedFirst string
+	}{
+		{[]string{"resource"}, "1.6.0"},
+		{[]string{"removed"}, "1.7.0"},
+		{[]string{"ephemeral"}, "1.11.0"},
+		{[]string{"resource", "lifecycle", "destroy"}, "1.12.0"},
+		{[]string{"terraform", "backend", "s3", "bucket"}, "1.6.0"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(strings.Join(tc.path, "."), func(t *testing.T) {
+			vr, ok := idx.VersionRange(tc.path...)
+			if !ok {
+				t.Fatal("expected element to be indexed")
+			}
+			if !vr.First.Equal(version.Must(version.NewVersion(tc.expectedFirst))) {
+				t.Fatalf("expected first version %s, given %s", tc.expectedFirst, vr.First)
+			}
+			if !vr.Last.Equal(LatestAvailableVersion.Core()) {
+				t.Fatalf("expected last version %s, given %s", LatestAvailableVersion.Core(), vr.Last)
+			}
+		})
+	}
+
+	if _, ok := idx.VersionRange("resource", "unknown"); ok {
+		t.Fatal("expected unknown element not to be indexed")
+	}
+}
+
+func TestCoreSchemaVersions_lastVersion(t *testing.T) {
+	v1_6_0 := version.Must(version.NewVersion("1.6.0"))
+	v1_7_0 := version.Must(version.NewVersion("1.7.0"))
+	idx := newCoreSchemaVersionIndex(version.Collection{
+		v1_7_0,
+		version.Must(version.NewVersion("1.7.0-beta1")),
+		v1_6_0,
+	})
+
+	vr, ok := idx.VersionRange("terraform", "backend", "s3", "use_legacy_workflow")
+	if !ok {
+		t.Fatal("expected element to be indexed")
+	}
+	if !vr.First.Equal(v1_6_0) || !vr.Last.Equal(v1_7_0) {
+		t.Fatalf("unexpected version range: %s - %s", vr.First, vr.Last)
+	}
+
+	elements := idx.Elements()
+	for i := 1; i < len(elements); i++ {
+		if elementKey(elements[i-1].Path) >= elementKey(elements[i].Path) {
+			t.Fatalf("expected elements sorted by path, given %q before %q", elements[i-1].Path, elements[i].Path)
+		}
+	}
+}
+
+func TestCoreSchemaVersionIndex_AnnotateDescriptions(t *testing.T) {
+	bs, err := CoreModuleSchemaForVersion(version.Must(version.NewVersion("1.12.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalDescription := bs.Blocks["removed"].Description.Value
+
+	annotated := CoreSchemaVersions().AnnotateDescriptions(bs)
+
+	removedDescription := annotated.Blocks["removed"].Description.Value
+	if !strings.HasSuffix(removedDescription, "Available since v1.7") {
+		t.Fatalf("expected annotated description, given %q", removedDescription)
+	}
+	destroyDescription := annotated.Blocks["resource"].Body.Blocks["lifecycle"].Body.Attributes["destroy"].Description.Value
+	if !strings.HasSuffix(destroyDescription, "Available since v1.12") {
+		t.Fatalf("expected annotated description, given %q", destroyDescription)
+	}
+	if resourceDescription := annotated.Blocks["resource"].Description.Value; strings.Contains(resourceDescription, "Available since") {
+		t.Fatalf("expected description of element from oldest version not to be annotated, given %q", resourceDescription)
+	}
+
+	if bs.Blocks["removed"].Description.Value != originalDescription {
+		t.Fatal("expected given schema not to be modified")
+	}
+}
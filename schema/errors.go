@@ -23,6 +23,20 @@ func (e coreFunctionsRequiredErr) Error() string {
 	return "core functions required (none provided)"
 }
 
+type backendRequiredErr struct{}
+
+func (e backendRequiredErr) Error() string {
+	return "backend required (none declared)"
+}
+
+type UnknownBackendTypeErr struct {
+	Type string
+}
+
+func (e UnknownBackendTypeErr) Error() string {
+	return fmt.Sprintf("unknown backend type: %q", e.Type)
+}
+
 type NoCompatibleSchemaErr struct {
 	Version     *version.Version
 	Constraints version.Constraints
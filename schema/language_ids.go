@@ -6,6 +6,9 @@
 package schema
 
 const (
-	ModuleLanguageID    = "opentofu"
-	VariablesLanguageID = "opentofu-vars"
+	ModuleLanguageID        = "opentofu"
+	VariablesLanguageID     = "opentofu-vars"
+	TestLanguageID          = "opentofu-test"
+	BackendConfigLanguageID = "opentofu-backend"
+	LockFileLanguageID      = "opentofu-lock"
 )
@@ -0,0 +1,26 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	lockfile_v0_14 "github.com/opentofu/opentofu-schema/internal/schema/lockfile/0.14"
+)
+
+// CoreLockFileSchemaForVersion finds a schema for the dependency lock file
+// (.terraform.lock.hcl) which is relevant for the given OpenTofu version.
+// It will return error if such schema cannot be found, i.e. for versions
+// which predate the lock file.
+func CoreLockFileSchemaForVersion(v *version.Version) (*schema.BodySchema, error) {
+	ver := v.Core()
+
+	if ver.GreaterThanOrEqual(v0_14) {
+		return lockfile_v0_14.LockFileSchema(ver), nil
+	}
+
+	return nil, NoCompatibleSchemaErr{Version: ver}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestCoreLockFileSchemaForVersion(t *testing.T) {
+	bs, err := CoreLockFileSchemaForVersion(version.Must(version.NewVersion("1.8.0")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, ok := bs.Blocks["provider"]
+	if !ok {
+		t.Fatal("expected provider block in lock file schema")
+	}
+	if !provider.Body.Attributes["version"].IsRequired {
+		t.Fatal("expected version attribute to be required")
+	}
+
+	_, err = CoreLockFileSchemaForVersion(version.Must(version.NewVersion("0.13.0")))
+	var versionErr NoCompatibleSchemaErr
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("expected NoCompatibleSchemaErr, got %#v", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	tfmod "github.com/opentofu/opentofu-schema/module"
+	"github.com/opentofu/opentofu-schema/registry"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// ValidateModuleCalls validates inputs of all module calls declared
+// in the given module against variables of the called modules.
+//
+// Module calls of modules which are neither available locally nor
+// fetched from the registry are skipped.
+func (m *SchemaMerger) ValidateModuleCalls(meta *tfmod.Meta) hcl.Diagnostics {
+	if meta == nil || m.stateReader == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(meta.ModuleCalls))
+	for name := range meta.ModuleCalls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diags hcl.Diagnostics
+	for _, name := range names {
+		mc := meta.ModuleCalls[name]
+		child, ok := m.resolveChildModule(meta.Path, mc)
+		if !ok {
+			continue
+		}
+
+		var variables map[string]tfmod.Variable
+		if child.meta != nil {
+			variables = child.meta.Variables
+		} else {
+			variables = registryModuleVariables(child.registryData)
+		}
+		diags = append(diags, ValidateModuleCall(mc, variables)...)
+	}
+
+	return diags
+}
+
+// ValidateModuleCall validates inputs of the given module call against
+// variables of the called module. It reports missing required inputs,
+// unknown inputs, inputs for deprecated variables and statically known
+// values which are not suitable for the variable type.
+func ValidateModuleCall(mc tfmod.DeclaredModuleCall, variables map[string]tfmod.Variable) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	inputNames := make([]string, 0, len(mc.Inputs))
+	for name := range mc.Inputs {
+		inputNames = append(inputNames, name)
+	}
+	sort.Strings(inputNames)
+
+	for _, name := range inputNames {
+		input := mc.Inputs[name]
+
+		v, ok := variables[name]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported argument",
+				Detail:   fmt.Sprintf("An argument named %q is not expected here. Module %q does not declare such variable.", name, mc.LocalName),
+				Subject:  input.NameRangePtr,
+			})
+			continue
+		}
+
+		if v.Deprecated != "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Variable marked as deprecated by the module author",
+				Detail:   fmt.Sprintf("Variable %q is marked as deprecated with the following message:\n%s", name, v.Deprecated),
+				Subject:  input.NameRangePtr,
+			})
+		}
+
+		diags = append(diags, validateModuleCallInputValue(mc.LocalName, name, input, v)...)
+	}
+
+	variableNames := make([]string, 0, len(variables))
+	for name := range variables {
+		variableNames = append(variableNames, name)
+	}
+	sort.Strings(variableNames)
+
+	for _, name := range variableNames {
+		if _, ok := mc.Inputs[name]; ok {
+			continue
+		}
+		if variables[name].DefaultValue != cty.NilVal {
+			continue
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing required argument",
+			Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", name),
+			Subject:  mc.DefRangePtr,
+		})
+	}
+
+	return diags
+}
+
+// validateModuleCallInputValue checks whether the value of the input,
+// if it is known statically, is suitable for the variable
+func validateModuleCallInputValue(moduleName, name string, input tfmod.ModuleCallInput, v tfmod.Variable) hcl.Diagnostics {
+	if input.Expr == nil {
+		return nil
+	}
+
+	// Only values which don't reference anything are known statically
+	val, valDiags := input.Expr.Value(nil)
+	if valDiags.HasErrors() || !val.IsWhollyKnown() {
+		return nil
+	}
+
+	if val.IsNull() {
+		if v.IsNullable {
+			return nil
+		}
+		return hcl.Diagnostics{
+			&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for input variable",
+				Detail:   fmt.Sprintf("The given value is null, but variable %q of module %q is not nullable.", name, moduleName),
+				Subject:  input.ValueRangePtr,
+			},
+		}
+	}
+
+	if v.Type == cty.NilType {
+		return nil
+	}
+	if v.TypeDefaults != nil {
+		val = v.TypeDefaults.Apply(val)
+	}
+	if _, err := convert.Convert(val, v.Type); err != nil {
+		return hcl.Diagnostics{
+			&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid value for input variable",
+				Detail:   fmt.Sprintf("The given value is not suitable for variable %q of module %q: %s.", name, moduleName, err),
+				Subject:  input.ValueRangePtr,
+			},
+		}
+	}
+
+	return nil
+}
+
+// registryModuleVariables represents inputs of a registry module as variables
+func registryModuleVariables(modData *registry.ModuleData) map[string]tfmod.Variable {
+	variables := make(map[string]tfmod.Variable, len(modData.Inputs))
+	for _, input := range modData.Inputs {
+		v := tfmod.Variable{
+			Type:       input.Type,
+			IsNullable: true,
+		}
+		if !input.Required {
+			v.DefaultValue = input.Default
+			if v.DefaultValue == cty.NilVal {
+				v.DefaultValue = cty.NullVal(cty.DynamicPseudoType)
+			}
+		}
+		variables[input.Name] = v
+	}
+	return variables
+}
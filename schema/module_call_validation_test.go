@@ -0,0 +1,245 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/earlydecoder"
+	"github.com/opentofu/opentofu-schema/module"
+	"github.com/opentofu/opentofu-schema/registry"
+	tfaddr "github.com/opentofu/registry-address"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestValidateModuleCall(t *testing.T) {
+	variables := map[string]module.Variable{
+		"name": {
+			Type:       cty.String,
+			IsNullable: true,
+		},
+		"tags": {
+			Type:         cty.Map(cty.String),
+			DefaultValue: cty.MapValEmpty(cty.String),
+			IsNullable:   true,
+		},
+		"size": {
+			Type:         cty.Number,
+			DefaultValue: cty.NumberIntVal(1),
+			IsNullable:   false,
+		},
+		"legacy": {
+			Type:         cty.String,
+			DefaultValue: cty.StringVal(""),
+			IsNullable:   true,
+			Deprecated:   "Use name instead.",
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		cfg              string
+		expectedFindings []string
+	}{
+		{
+			"valid",
+			`
+module "child" {
+  source = "./child"
+  name   = var.name
+  tags   = { env = "prod" }
+  size   = "2"
+}
+`,
+			[]string{},
+		},
+		{
+			"missing required input",
+			`
+module "child" {
+  source = "./child"
+}
+`,
+			[]string{
+				`error: Missing required argument: The argument "name" is required, but no definition was found.`,
+			},
+		},
+		{
+			"unknown input",
+			`
+module "child" {
+  source  = "./child"
+  name    = "foo"
+  unknown = true
+}
+`,
+			[]string{
+				`error: Unsupported argument: An argument named "unknown" is not expected here. Module "child" does not declare such variable.`,
+			},
+		},
+		{
+			"deprecated input",
+			`
+module "child" {
+  source = "./child"
+  name   = "foo"
+  legacy = "bar"
+}
+`,
+			[]string{
+				"warning: Variable marked as deprecated by the module author: Variable \"legacy\" is marked as deprecated with the following message:\nUse name instead.",
+			},
+		},
+		{
+			"type mismatch",
+			`
+module "child" {
+  source = "./child"
+  name   = ["foo"]
+  tags   = local.tags
+  size   = null
+}
+`,
+			[]string{
+				`error: Invalid value for input variable: The given value is not suitable for variable "name" of module "child": string required, but have tuple.`,
+				`error: Invalid value for input variable: The given value is null, but variable "size" of module "child" is not nullable.`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := loadModuleMeta(t, tc.cfg)
+
+			diags := ValidateModuleCall(meta.ModuleCalls["child"], variables)
+			if diff := cmp.Diff(tc.expectedFindings, diagnosticFindings(diags)); diff != "" {
+				t.Fatalf("unexpected diagnostics: %s", diff)
+			}
+			for _, diag := range diags {
+				if diag.Subject == nil {
+					t.Fatalf("expected diagnostic %q to have a subject", diag.Summary)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaMerger_ValidateModuleCalls(t *testing.T) {
+	meta := loadModuleMeta(t, `
+module "local" {
+  source = "./local"
+  unknown = 1
+}
+
+module "registry" {
+  source  = "namespace/foo/bar"
+  version = "1.0.0"
+}
+
+module "unavailable" {
+  source = "./missing"
+}
+`)
+
+	sm := NewSchemaMerger(testCoreSchema())
+	sm.SetStateReader(&moduleCallsStateReader{
+		localModules: map[string]*module.Meta{
+			"local": {
+				Variables: map[string]module.Variable{
+					"name": {
+						Type:         cty.String,
+						DefaultValue: cty.StringVal("foo"),
+						IsNullable:   true,
+					},
+				},
+			},
+		},
+		registryModules: map[string]*registry.ModuleData{
+			"registry.opentofu.org/namespace/foo/bar": {
+				Inputs: []registry.Input{
+					{
+						Name:     "required",
+						Type:     cty.String,
+						Required: true,
+					},
+					{
+						Name: "optional",
+						Type: cty.String,
+					},
+				},
+			},
+		},
+	})
+
+	expectedFindings := []string{
+		`error: Unsupported argument: An argument named "unknown" is not expected here. Module "local" does not declare such variable.`,
+		`error: Missing required argument: The argument "required" is required, but no definition was found.`,
+	}
+	if diff := cmp.Diff(expectedFindings, diagnosticFindings(sm.ValidateModuleCalls(meta))); diff != "" {
+		t.Fatalf("unexpected diagnostics: %s", diff)
+	}
+}
+
+func loadModuleMeta(t *testing.T, cfg string) *module.Meta {
+	f, diags := hclsyntax.ParseConfig([]byte(cfg), "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	meta, diags := earlydecoder.LoadModule(".", map[string]*hcl.File{"main.tf": f})
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	return meta
+}
+
+func diagnosticFindings(diags hcl.Diagnostics) []string {
+	findings := make([]string, 0, len(diags))
+	for _, diag := range diags {
+		severity := "error"
+		if diag.Severity == hcl.DiagWarning {
+			severity = "warning"
+		}
+		findings = append(findings, fmt.Sprintf("%s: %s: %s", severity, diag.Summary, diag.Detail))
+	}
+	return findings
+}
+
+type moduleCallsStateReader struct {
+	localModules    map[string]*module.Meta
+	registryModules map[string]*registry.ModuleData
+}
+
+func (sr *moduleCallsStateReader) DeclaredModuleCalls(modPath string) (map[string]module.DeclaredModuleCall, error) {
+	return nil, nil
+}
+
+func (sr *moduleCallsStateReader) InstalledModulePath(rootPath string, normalizedSource string) (string, bool) {
+	return "", false
+}
+
+func (sr *moduleCallsStateReader) LocalModuleMeta(modPath string) (*module.Meta, error) {
+	if meta, ok := sr.localModules[filepath.Base(modPath)]; ok {
+		return meta, nil
+	}
+	return nil, fmt.Errorf("module not found: %s", modPath)
+}
+
+func (sr *moduleCallsStateReader) RegistryModuleMeta(addr tfaddr.Module, cons version.Constraints) (*registry.ModuleData, error) {
+	if data, ok := sr.registryModules[addr.String()]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("module not found: %s", addr)
+}
+
+func (sr *moduleCallsStateReader) ProviderSchema(modPath string, addr tfaddr.Provider, vc version.Constraints) (*ProviderSchema, error) {
+	return nil, fmt.Errorf("provider schema not found: %s", addr)
+}
@@ -159,6 +159,9 @@ func schemaForDependentModuleBlock(module module.DeclaredModuleCall, modMeta *mo
 		typ := cty.DynamicPseudoType
 		if !output.Value.IsNull() {
 			typ = output.Value.Type()
+		} else if output.Type != cty.NilType {
+			// The value may be unknown, while its type is not
+			typ = output.Type
 		}
 
 		targetable := &schema.Targetable{
@@ -215,8 +218,11 @@ func schemaForDependentModuleBlock(module module.DeclaredModuleCall, modMeta *mo
 	if len(modMeta.Filenames) > 0 {
 		filename := modMeta.Filenames[0]
 
-		// Prioritize main.tf based on best practices as documented at
-		if sliceContains(modMeta.Filenames, "main.tf") {
+		// Prioritize main.tofu, which takes precedence over main.tf,
+		// and then main.tf, the conventional entry point of a module
+		if sliceContains(modMeta.Filenames, "main.tofu") {
+			filename = "main.tofu"
+		} else if sliceContains(modMeta.Filenames, "main.tf") {
 			filename = "main.tf"
 		}
 
@@ -0,0 +1,113 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	tfjson "github.com/hashicorp/terraform-json"
+	tfaddr "github.com/opentofu/registry-address"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// DiffProviderSchemas compares two versions of a provider schema,
+// e.g. before and after a provider upgrade, and returns all changes
+// of resources, data sources, ephemeral resources and functions
+func DiffProviderSchemas(old, new *ProviderSchema) *SchemaDiff {
+	if old == nil {
+		old = &ProviderSchema{}
+	}
+	if new == nil {
+		new = &ProviderSchema{}
+	}
+
+	sd := &schemaDiffer{}
+	sd.diffBody([]string{string(SchemaElementProvider)}, old.Provider, new.Provider)
+	sd.diffBodies(SchemaElementResource, "resource", old.Resources, new.Resources)
+	sd.diffBodies(SchemaElementDataSource, "data", old.DataSources, new.DataSources)
+	sd.diffBodies(SchemaElementEphemeralResource, "ephemeral", old.EphemeralResources, new.EphemeralResources)
+	sd.diffFunctions(old.Functions, new.Functions)
+
+	return sd.diff()
+}
+
+// DiffProviderSchemasFromJson compares two versions of a provider schema
+// as obtained from `tofu providers schema -json`
+func DiffProviderSchemasFromJson(old, new *tfjson.ProviderSchema, pAddr tfaddr.Provider) *SchemaDiff {
+	if old == nil {
+		old = &tfjson.ProviderSchema{}
+	}
+	if new == nil {
+		new = &tfjson.ProviderSchema{}
+	}
+	return DiffProviderSchemas(ProviderSchemaFromJson(old, pAddr), ProviderSchemaFromJson(new, pAddr))
+}
+
+func (sd *schemaDiffer) diffFunctions(old, new map[string]*schema.FunctionSignature) {
+	for _, name := range sortedKeys(old) {
+		if _, ok := new[name]; !ok {
+			sd.add(SchemaChangeRemoved, SchemaElementFunction, []string{"function", name}, true)
+		}
+	}
+	for _, name := range sortedKeys(new) {
+		oldSig, ok := old[name]
+		if !ok {
+			sd.add(SchemaChangeAdded, SchemaElementFunction, []string{"function", name}, false)
+			continue
+		}
+		sd.diffFunction([]string{"function", name}, oldSig, new[name])
+	}
+}
+
+func (sd *schemaDiffer) diffFunction(path []string, old, new *schema.FunctionSignature) {
+	if old == nil {
+		old = &schema.FunctionSignature{}
+	}
+	if new == nil {
+		new = &schema.FunctionSignature{}
+	}
+
+	if oldType, newType := typeName(old.ReturnType), typeName(new.ReturnType); oldType != newType {
+		sd.addChanged(SchemaChangeTypeChanged, SchemaElementFunction, path, oldType, newType, true)
+	}
+
+	// Parameters are positional, so they are compared by position
+	// and identified by their (new) name where available
+	for i := 0; i < len(old.Params) || i < len(new.Params); i++ {
+		switch {
+		case i >= len(new.Params):
+			sd.add(SchemaChangeRemoved, SchemaElementParameter, append(path, paramName(old.Params[i], i)), true)
+		case i >= len(old.Params):
+			// Existing calls don't pass any argument for the new parameter
+			sd.add(SchemaChangeNowRequired, SchemaElementParameter, append(path, paramName(new.Params[i], i)), true)
+		default:
+			sd.diffParameter(append(path, paramName(new.Params[i], i)), &old.Params[i], &new.Params[i])
+		}
+	}
+
+	switch {
+	case old.VarParam != nil && new.VarParam == nil:
+		sd.add(SchemaChangeRemoved, SchemaElementParameter, append(path, paramName(*old.VarParam, len(old.Params))), true)
+	case old.VarParam == nil && new.VarParam != nil:
+		sd.add(SchemaChangeAdded, SchemaElementParameter, append(path, paramName(*new.VarParam, len(new.Params))), false)
+	case old.VarParam != nil && new.VarParam != nil:
+		sd.diffParameter(append(path, paramName(*new.VarParam, len(new.Params))), old.VarParam, new.VarParam)
+	}
+}
+
+func (sd *schemaDiffer) diffParameter(path []string, old, new *function.Parameter) {
+	if oldType, newType := typeName(old.Type), typeName(new.Type); oldType != newType {
+		sd.addChanged(SchemaChangeTypeChanged, SchemaElementParameter, path, oldType, newType, true)
+	}
+}
+
+func paramName(param function.Parameter, i int) string {
+	if param.Name != "" {
+		return param.Name
+	}
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,336 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/opentofu/opentofu-schema/internal/addr"
+)
+
+func TestDiffProviderSchemasFromJson(t *testing.T) {
+	oldSchema := parseProviderSchemaJson(t, `{
+	"provider": {
+		"block": {
+			"attributes": {
+				"region": {"type": "string", "optional": true}
+			}
+		}
+	},
+	"resource_schemas": {
+		"stub_instance": {
+			"block": {
+				"attributes": {
+					"name": {"type": "string", "optional": true},
+					"size": {"type": "number", "optional": true},
+					"legacy_id": {"type": "string", "computed": true},
+					"rules": {"type": ["list", ["object", {"port": "number"}]], "optional": true},
+					"range": {"type": ["tuple", ["number", "number"]], "optional": true},
+					"tags": {
+						"nested_type": {
+							"nesting_mode": "single",
+							"attributes": {
+								"owner": {"type": "string", "optional": true}
+							}
+						},
+						"optional": true
+					}
+				},
+				"block_types": {
+					"network": {
+						"nesting_mode": "list",
+						"block": {
+							"attributes": {
+								"subnet_id": {"type": "string", "optional": true}
+							}
+						}
+					}
+				}
+			}
+		},
+		"stub_volume": {
+			"block": {}
+		}
+	},
+	"data_source_schemas": {
+		"stub_image": {
+			"block": {}
+		}
+	},
+	"functions": {
+		"parse_id": {
+			"return_type": "string",
+			"parameters": [
+				{"name": "id", "type": "string"}
+			]
+		},
+		"parse_arn": {
+			"return_type": ["object", {"service": "string"}],
+			"parameters": [
+				{"name": "arn", "type": ["tuple", ["string"]]}
+			]
+		}
+	}
+}`)
+	newSchema := parseProviderSchemaJson(t, `{
+	"provider": {
+		"block": {
+			"attributes": {
+				"region": {"type": "string", "required": true}
+			}
+		}
+	},
+	"resource_schemas": {
+		"stub_instance": {
+			"block": {
+				"attributes": {
+					"name": {"type": "string", "optional": true, "deprecated": true},
+					"size": {"type": "string", "optional": true},
+					"zone": {"type": "string", "optional": true},
+					"rules": {"type": ["list", ["object", {"port": "string"}]], "optional": true},
+					"range": {"type": ["tuple", ["number", "string"]], "optional": true},
+					"tags": {
+						"nested_type": {
+							"nesting_mode": "list",
+							"attributes": {
+								"owner": {"type": "string", "optional": true},
+								"team": {"type": "string", "required": true}
+							}
+						},
+						"optional": true
+					}
+				},
+				"block_types": {
+					"network": {
+						"nesting_mode": "set",
+						"block": {
+							"attributes": {
+								"subnet_id": {"type": "string", "optional": true}
+							}
+						}
+					}
+				}
+			}
+		}
+	},
+	"data_source_schemas": {
+		"stub_image": {
+			"block": {
+				"deprecated": true
+			}
+		}
+	},
+	"ephemeral_resource_schemas": {
+		"stub_token": {
+			"block": {}
+		}
+	},
+	"functions": {
+		"parse_id": {
+			"return_type": ["list", "string"],
+			"parameters": [
+				{"name": "id", "type": "string"},
+				{"name": "separator", "type": "string"}
+			]
+		},
+		"parse_arn": {
+			"return_type": ["object", {"service": "string", "region": "string"}],
+			"parameters": [
+				{"name": "arn", "type": ["tuple", ["string", "string"]]}
+			]
+		}
+	}
+}`)
+
+	diff := DiffProviderSchemasFromJson(oldSchema, newSchema, addr.NewDefaultProvider("stub"))
+
+	expectedChanges := []SchemaChange{
+		{
+			Kind:    SchemaChangeDeprecated,
+			Element: SchemaElementDataSource,
+			Path:    []string{"data", "stub_image"},
+		},
+		{
+			Kind:    SchemaChangeAdded,
+			Element: SchemaElementEphemeralResource,
+			Path:    []string{"ephemeral", "stub_token"},
+		},
+		{
+			Kind:     SchemaChangeTypeChanged,
+			Element:  SchemaElementFunction,
+			Path:     []string{"function", "parse_arn"},
+			Old:      "object({service=string})",
+			New:      "object({region=string,service=string})",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeTypeChanged,
+			Element:  SchemaElementParameter,
+			Path:     []string{"function", "parse_arn", "arn"},
+			Old:      "tuple([string])",
+			New:      "tuple([string,string])",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeTypeChanged,
+			Element:  SchemaElementFunction,
+			Path:     []string{"function", "parse_id"},
+			Old:      "string",
+			New:      "list(string)",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeNowRequired,
+			Element:  SchemaElementParameter,
+			Path:     []string{"function", "parse_id", "separator"},
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeNowRequired,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"provider", "region"},
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeRemoved,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"resource", "stub_instance", "legacy_id"},
+			Breaking: true,
+		},
+		{
+			Kind:    SchemaChangeDeprecated,
+			Element: SchemaElementAttribute,
+			Path:    []string{"resource", "stub_instance", "name"},
+		},
+		{
+			Kind:     SchemaChangeNestingChanged,
+			Element:  SchemaElementBlock,
+			Path:     []string{"resource", "stub_instance", "network"},
+			Old:      "list",
+			New:      "set",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeTypeChanged,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"resource", "stub_instance", "range"},
+			Old:      "tuple([number,number])",
+			New:      "tuple([number,string])",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeTypeChanged,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"resource", "stub_instance", "rules"},
+			Old:      "list(object({port=number}))",
+			New:      "list(object({port=string}))",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeTypeChanged,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"resource", "stub_instance", "size"},
+			Old:      "number",
+			New:      "string",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeNestingChanged,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"resource", "stub_instance", "tags"},
+			Old:      "single",
+			New:      "list",
+			Breaking: true,
+		},
+		{
+			Kind:     SchemaChangeNowRequired,
+			Element:  SchemaElementAttribute,
+			Path:     []string{"resource", "stub_instance", "tags", "team"},
+			Breaking: true,
+		},
+		{
+			Kind:    SchemaChangeAdded,
+			Element: SchemaElementAttribute,
+			Path:    []string{"resource", "stub_instance", "zone"},
+		},
+		{
+			Kind:     SchemaChangeRemoved,
+			Element:  SchemaElementResource,
+			Path:     []string{"resource", "stub_volume"},
+			Breaking: true,
+		},
+	}
+
+	if diff := cmp.Diff(expectedChanges, diff.Changes); diff != "" {
+		t.Fatalf("unexpected changes: %s", diff)
+	}
+	if !diff.HasBreakingChanges() {
+		t.Fatal("expected breaking changes")
+	}
+}
+
+func TestDiffProviderSchemas_noChanges(t *testing.T) {
+	ps := parseProviderSchemaJson(t, `{
+	"resource_schemas": {
+		"stub_instance": {
+			"block": {
+				"attributes": {
+					"rules": {"type": ["list", ["object", {"port": "number"}]], "optional": true}
+				}
+			}
+		}
+	}
+}`)
+	pAddr := addr.NewDefaultProvider("stub")
+
+	diff := DiffProviderSchemas(ProviderSchemaFromJson(ps, pAddr), ProviderSchemaFromJson(ps, pAddr))
+	if len(diff.Changes) != 0 {
+		t.Fatalf("expected no changes, given %#v", diff.Changes)
+	}
+	if diff.Markdown() != "No changes.\n" {
+		t.Fatalf("unexpected markdown: %q", diff.Markdown())
+	}
+}
+
+func TestSchemaDiff_Markdown(t *testing.T) {
+	diff := &SchemaDiff{
+		Changes: []SchemaChange{
+			{
+				Kind:    SchemaChangeAdded,
+				Element: SchemaElementDataSource,
+				Path:    []string{"data", "stub_image"},
+			},
+			{
+				Kind:     SchemaChangeTypeChanged,
+				Element:  SchemaElementAttribute,
+				Path:     []string{"resource", "stub_instance", "size"},
+				Old:      "number",
+				New:      "string",
+				Breaking: true,
+			},
+		},
+	}
+
+	expectedMarkdown := "## Breaking changes\n\n" +
+		"- `resource.stub_instance.size`: type changed from `number` to `string`\n" +
+		"\n## Other changes\n\n" +
+		"- `data.stub_image`: data source added\n"
+
+	if diff := cmp.Diff(expectedMarkdown, diff.Markdown()); diff != "" {
+		t.Fatalf("unexpected markdown: %s", diff)
+	}
+}
+
+func parseProviderSchemaJson(t *testing.T, rawSchema string) *tfjson.ProviderSchema {
+	var ps tfjson.ProviderSchema
+	if err := json.Unmarshal([]byte(rawSchema), &ps); err != nil {
+		t.Fatal(err)
+	}
+	return &ps
+}
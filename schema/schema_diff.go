@@ -0,0 +1,566 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl-lang/schema"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type SchemaChangeKind string
+
+const (
+	SchemaChangeAdded          SchemaChangeKind = "added"
+	SchemaChangeRemoved        SchemaChangeKind = "removed"
+	SchemaChangeNowRequired    SchemaChangeKind = "now_required"
+	SchemaChangeTypeChanged    SchemaChangeKind = "type_changed"
+	SchemaChangeNestingChanged SchemaChangeKind = "nesting_changed"
+	SchemaChangeLabelsChanged  SchemaChangeKind = "labels_changed"
+	SchemaChangeDeprecated     SchemaChangeKind = "deprecated"
+)
+
+type SchemaElementKind string
+
+const (
+	SchemaElementProvider          SchemaElementKind = "provider"
+	SchemaElementResource          SchemaElementKind = "resource"
+	SchemaElementDataSource        SchemaElementKind = "data_source"
+	SchemaElementEphemeralResource SchemaElementKind = "ephemeral_resource"
+	SchemaElementFunction          SchemaElementKind = "function"
+	SchemaElementParameter         SchemaElementKind = "parameter"
+	SchemaElementBlock             SchemaElementKind = "block"
+	SchemaElementAttribute         SchemaElementKind = "attribute"
+	// SchemaElementDependentBody is a body which depends on the labels
+	// or attributes of its block, e.g. the body of a particular backend
+	SchemaElementDependentBody SchemaElementKind = "dependent_body"
+)
+
+// SchemaChange describes a single difference between two schemas
+type SchemaChange struct {
+	Kind    SchemaChangeKind  `json:"kind"`
+	Element SchemaElementKind `json:"element"`
+	// Path is the path of the changed element, starting
+	// with its top-level scope, e.g. ["resource", "aws_instance", "ami"]
+	Path []string `json:"path"`
+	// Old and New describe the type, nesting or labels before
+	// and after a change of kind SchemaChangeTypeChanged,
+	// SchemaChangeNestingChanged or SchemaChangeLabelsChanged
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+	// Breaking is true if configuration valid for the old
+	// schema may no longer be valid for the new one
+	Breaking bool `json:"breaking"`
+}
+
+func (c SchemaChange) String() string {
+	path := "`" + strings.Join(c.Path, ".") + "`"
+	element := strings.ReplaceAll(string(c.Element), "_", " ")
+
+	switch c.Kind {
+	case SchemaChangeAdded:
+		return fmt.Sprintf("%s: %s added", path, element)
+	case SchemaChangeRemoved:
+		return fmt.Sprintf("%s: %s removed", path, element)
+	case SchemaChangeNowRequired:
+		return fmt.Sprintf("%s: %s is now required", path, element)
+	case SchemaChangeTypeChanged:
+		return fmt.Sprintf("%s: type changed from `%s` to `%s`", path, c.Old, c.New)
+	case SchemaChangeNestingChanged:
+		return fmt.Sprintf("%s: nesting changed from `%s` to `%s`", path, c.Old, c.New)
+	case SchemaChangeLabelsChanged:
+		return fmt.Sprintf("%s: labels changed from `%s` to `%s`", path, c.Old, c.New)
+	case SchemaChangeDeprecated:
+		return fmt.Sprintf("%s: %s deprecated", path, element)
+	}
+	return fmt.Sprintf("%s: %s %s", path, element, c.Kind)
+}
+
+// SchemaDiff is a list of changes between two schemas,
+// sorted by path
+type SchemaDiff struct {
+	Changes []SchemaChange `json:"changes"`
+}
+
+// HasBreakingChanges returns true if any of the changes is breaking
+func (d *SchemaDiff) HasBreakingChanges() bool {
+	for _, c := range d.Changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Markdown renders the diff as a markdown document,
+// listing breaking changes first
+func (d *SchemaDiff) Markdown() string {
+	if len(d.Changes) == 0 {
+		return "No changes.\n"
+	}
+
+	var breaking, other []string
+	for _, c := range d.Changes {
+		if c.Breaking {
+			breaking = append(breaking, c.String())
+		} else {
+			other = append(other, c.String())
+		}
+	}
+
+	var sb strings.Builder
+	writeSection := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "## %s\n\n", title)
+		for _, item := range items {
+			fmt.Fprintf(&sb, "- %s\n", item)
+		}
+	}
+	writeSection("Breaking changes", breaking)
+	writeSection("Other changes", other)
+
+	return sb.String()
+}
+
+func (d *SchemaDiff) sort() {
+	sort.SliceStable(d.Changes, func(i, j int) bool {
+		return strings.Join(d.Changes[i].Path, "\x00") < strings.Join(d.Changes[j].Path, "\x00")
+	})
+}
+
+type schemaDiffer struct {
+	changes []SchemaChange
+}
+
+func (sd *schemaDiffer) add(kind SchemaChangeKind, element SchemaElementKind, path []string, breaking bool) {
+	sd.changes = append(sd.changes, SchemaChange{
+		Kind:     kind,
+		Element:  element,
+		Path:     copyPath(path),
+		Breaking: breaking,
+	})
+}
+
+func (sd *schemaDiffer) addChanged(kind SchemaChangeKind, element SchemaElementKind, path []string, old, new string, breaking bool) {
+	sd.changes = append(sd.changes, SchemaChange{
+		Kind:     kind,
+		Element:  element,
+		Path:     copyPath(path),
+		Old:      old,
+		New:      new,
+		Breaking: breaking,
+	})
+}
+
+func (sd *schemaDiffer) diff() *SchemaDiff {
+	d := &SchemaDiff{Changes: sd.changes}
+	if d.Changes == nil {
+		d.Changes = []SchemaChange{}
+	}
+	d.sort()
+	return d
+}
+
+// diffBodies compares two bodies of the same top-level element
+// (e.g. a resource), whose addition or removal is reported
+// under the given element kind
+func (sd *schemaDiffer) diffBodies(element SchemaElementKind, scope string, old, new map[string]*schema.BodySchema) {
+	for _, name := range sortedKeys(old) {
+		if _, ok := new[name]; !ok {
+			sd.add(SchemaChangeRemoved, element, []string{scope, name}, true)
+		}
+	}
+	for _, name := range sortedKeys(new) {
+		oldBody, ok := old[name]
+		if !ok {
+			sd.add(SchemaChangeAdded, element, []string{scope, name}, false)
+			continue
+		}
+		newBody := new[name]
+		if !isDeprecatedBody(oldBody) && isDeprecatedBody(newBody) {
+			sd.add(SchemaChangeDeprecated, element, []string{scope, name}, false)
+		}
+		sd.diffBody([]string{scope, name}, oldBody, newBody)
+	}
+}
+
+func (sd *schemaDiffer) diffBody(path []string, old, new *schema.BodySchema) {
+	if old == nil {
+		old = &schema.BodySchema{}
+	}
+	if new == nil {
+		new = &schema.BodySchema{}
+	}
+
+	sd.diffAttributes(path, old.Attributes, new.Attributes)
+
+	for _, name := range sortedKeys(old.Blocks) {
+		if _, ok := new.Blocks[name]; ok {
+			continue
+		}
+		if isConvertedAttribute(name, old, new) {
+			continue
+		}
+		sd.add(SchemaChangeRemoved, SchemaElementBlock, append(path, name), true)
+	}
+	for _, name := range sortedKeys(new.Blocks) {
+		if isConvertedAttribute(name, old, new) {
+			continue
+		}
+		newBlock := new.Blocks[name]
+		oldBlock, ok := old.Blocks[name]
+		if !ok {
+			if newBlock.MinItems > 0 {
+				sd.add(SchemaChangeNowRequired, SchemaElementBlock, append(path, name), true)
+			} else {
+				sd.add(SchemaChangeAdded, SchemaElementBlock, append(path, name), false)
+			}
+			continue
+		}
+		sd.diffBlock(append(path, name), oldBlock, newBlock)
+	}
+}
+
+func isDeprecatedBody(body *schema.BodySchema) bool {
+	return body != nil && body.IsDeprecated
+}
+
+// isDeprecatedBlock returns true if either the block or its body
+// is deprecated, since both are set for blocks of provider schemas
+func isDeprecatedBlock(block *schema.BlockSchema) bool {
+	return block.IsDeprecated || isDeprecatedBody(block.Body)
+}
+
+// isConvertedAttribute returns true if the block of the given name
+// only exists as an alternative syntax of a list(object)
+// or set(object) attribute (see convertibleAttributesToBlocks),
+// whose changes are reported with the attribute
+func isConvertedAttribute(name string, old, new *schema.BodySchema) bool {
+	if _, ok := old.Attributes[name]; ok {
+		return true
+	}
+	_, ok := new.Attributes[name]
+	return ok
+}
+
+func (sd *schemaDiffer) diffBlock(path []string, old, new *schema.BlockSchema) {
+	if oldNesting, newNesting := blockNesting(old), blockNesting(new); oldNesting != newNesting {
+		sd.addChanged(SchemaChangeNestingChanged, SchemaElementBlock, path, oldNesting, newNesting, true)
+	} else if len(old.Labels) != len(new.Labels) {
+		// Labels of map blocks follow from their nesting, so they
+		// are only compared for unchanged nesting. Renamed labels
+		// don't affect configuration and are not reported.
+		sd.addChanged(SchemaChangeLabelsChanged, SchemaElementBlock, path, blockLabels(old), blockLabels(new), true)
+	}
+	if old.MinItems == 0 && new.MinItems > 0 {
+		sd.add(SchemaChangeNowRequired, SchemaElementBlock, path, true)
+	}
+	if !isDeprecatedBlock(old) && isDeprecatedBlock(new) {
+		sd.add(SchemaChangeDeprecated, SchemaElementBlock, path, false)
+	}
+	sd.diffBody(path, old.Body, new.Body)
+	sd.diffDependentBodies(path, old.DependentBody, new.DependentBody)
+}
+
+func (sd *schemaDiffer) diffDependentBodies(path []string, old, new map[schema.SchemaKey]*schema.BodySchema) {
+	oldBodies := dependentBodiesByName(old)
+	newBodies := dependentBodiesByName(new)
+
+	for _, name := range sortedKeys(oldBodies) {
+		if _, ok := newBodies[name]; !ok {
+			sd.add(SchemaChangeRemoved, SchemaElementDependentBody, append(path, name), true)
+		}
+	}
+	for _, name := range sortedKeys(newBodies) {
+		oldBody, ok := oldBodies[name]
+		if !ok {
+			sd.add(SchemaChangeAdded, SchemaElementDependentBody, append(path, name), false)
+			continue
+		}
+		newBody := newBodies[name]
+		if !isDeprecatedBody(oldBody) && isDeprecatedBody(newBody) {
+			sd.add(SchemaChangeDeprecated, SchemaElementDependentBody, append(path, name), false)
+		}
+		sd.diffBody(append(path, name), oldBody, newBody)
+	}
+}
+
+// dependentBodiesByName indexes dependent bodies by a readable
+// name of their key, e.g. "s3" for the body of the s3 backend
+func dependentBodiesByName(bodies map[schema.SchemaKey]*schema.BodySchema) map[string]*schema.BodySchema {
+	named := make(map[string]*schema.BodySchema, len(bodies))
+	for key, body := range bodies {
+		named[dependencyKeysName(key)] = body
+	}
+	return named
+}
+
+func dependencyKeysName(key schema.SchemaKey) string {
+	var dk struct {
+		Labels []struct {
+			Value string `json:"value"`
+		} `json:"labels"`
+		Attributes []struct {
+			Name string `json:"name"`
+			Expr struct {
+				Static  string `json:"static"`
+				Address string `json:"addr"`
+			} `json:"expr"`
+		} `json:"attrs"`
+	}
+	if err := json.Unmarshal([]byte(key), &dk); err != nil {
+		return string(key)
+	}
+
+	parts := make([]string, 0, len(dk.Labels)+len(dk.Attributes))
+	for _, label := range dk.Labels {
+		parts = append(parts, label.Value)
+	}
+	for _, attr := range dk.Attributes {
+		value := attr.Expr.Static
+		if value == "" {
+			value = attr.Expr.Address
+		}
+		parts = append(parts, attr.Name+"="+value)
+	}
+	if len(parts) == 0 {
+		return string(key)
+	}
+	return strings.Join(parts, ",")
+}
+
+func blockLabels(block *schema.BlockSchema) string {
+	if len(block.Labels) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(block.Labels))
+	for _, label := range block.Labels {
+		names = append(names, label.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (sd *schemaDiffer) diffAttributes(path []string, old, new map[string]*schema.AttributeSchema) {
+	for _, name := range sortedKeys(old) {
+		if _, ok := new[name]; !ok {
+			sd.add(SchemaChangeRemoved, SchemaElementAttribute, append(path, name), true)
+		}
+	}
+	for _, name := range sortedKeys(new) {
+		newAttr := new[name]
+		oldAttr, ok := old[name]
+		if !ok {
+			if newAttr.IsRequired {
+				sd.add(SchemaChangeNowRequired, SchemaElementAttribute, append(path, name), true)
+			} else {
+				sd.add(SchemaChangeAdded, SchemaElementAttribute, append(path, name), false)
+			}
+			continue
+		}
+		sd.diffAttribute(append(path, name), oldAttr, newAttr)
+	}
+}
+
+func (sd *schemaDiffer) diffAttribute(path []string, old, new *schema.AttributeSchema) {
+	if !old.IsRequired && new.IsRequired {
+		sd.add(SchemaChangeNowRequired, SchemaElementAttribute, path, true)
+	}
+	if !old.IsDeprecated && new.IsDeprecated {
+		sd.add(SchemaChangeDeprecated, SchemaElementAttribute, path, false)
+	}
+
+	oldNesting, oldAttrs, oldNested := nestedAttributes(old.Constraint)
+	newNesting, newAttrs, newNested := nestedAttributes(new.Constraint)
+	if oldNested && newNested {
+		if oldNesting != newNesting {
+			sd.addChanged(SchemaChangeNestingChanged, SchemaElementAttribute, path, oldNesting, newNesting, true)
+		}
+		sd.diffAttributes(path, oldAttrs, newAttrs)
+		return
+	}
+
+	if oldType, newType := constraintName(old.Constraint), constraintName(new.Constraint); oldType != newType {
+		breaking := !isConstraintWidened(old.Constraint, new.Constraint)
+		sd.addChanged(SchemaChangeTypeChanged, SchemaElementAttribute, path, oldType, newType, breaking)
+	}
+}
+
+// isConstraintWidened returns true if the new constraint only adds
+// alternatives to the old one, e.g. another type of reference,
+// so that all values valid for the old one remain valid
+func isConstraintWidened(old, new schema.Constraint) bool {
+	switch o := old.(type) {
+	case schema.List:
+		if n, ok := new.(schema.List); ok {
+			return isConstraintWidened(o.Elem, n.Elem)
+		}
+	case schema.Set:
+		if n, ok := new.(schema.Set); ok {
+			return isConstraintWidened(o.Elem, n.Elem)
+		}
+	case schema.Map:
+		if n, ok := new.(schema.Map); ok {
+			return isConstraintWidened(o.Elem, n.Elem)
+		}
+	}
+
+	newAlternatives := make(map[string]bool)
+	for _, name := range constraintAlternatives(new) {
+		newAlternatives[name] = true
+	}
+	for _, name := range constraintAlternatives(old) {
+		if !newAlternatives[name] {
+			return false
+		}
+	}
+	return true
+}
+
+func constraintAlternatives(cons schema.Constraint) []string {
+	oneOf, ok := cons.(schema.OneOf)
+	if !ok || isTypedOneOf(oneOf) {
+		return []string{constraintName(cons)}
+	}
+	names := make([]string, 0, len(oneOf))
+	for _, elem := range oneOf {
+		names = append(names, constraintName(elem))
+	}
+	return names
+}
+
+// isTypedOneOf returns true for constraints converted from types
+// of provider schemas, which are represented by an expression
+// of the type, followed by its literal forms
+func isTypedOneOf(cons schema.OneOf) bool {
+	for _, elem := range cons {
+		if expr, ok := elem.(schema.AnyExpression); ok && expr.OfType != cty.NilType {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedAttributes returns the attributes of an object constraint,
+// as produced for nested attribute types, along with its nesting mode
+func nestedAttributes(cons schema.Constraint) (string, schema.ObjectAttributes, bool) {
+	switch c := cons.(type) {
+	case schema.Object:
+		return "single", c.Attributes, true
+	case schema.List:
+		if obj, ok := c.Elem.(schema.Object); ok {
+			return "list", obj.Attributes, true
+		}
+	case schema.Set:
+		if obj, ok := c.Elem.(schema.Object); ok {
+			return "set", obj.Attributes, true
+		}
+	case schema.Map:
+		if obj, ok := c.Elem.(schema.Object); ok {
+			return "map", obj.Attributes, true
+		}
+	}
+	return "", nil, false
+}
+
+// constraintName returns a human-readable name of the given
+// constraint, which is equal for equivalent constraints
+func constraintName(cons schema.Constraint) string {
+	switch c := cons.(type) {
+	case nil:
+		return "none"
+	case schema.AnyExpression:
+		return typeName(c.OfType)
+	case schema.LiteralType:
+		return typeName(c.Type)
+	case schema.Keyword:
+		return c.Keyword
+	case schema.Reference:
+		if c.OfScopeId != "" {
+			return "reference to " + string(c.OfScopeId)
+		}
+		return "reference"
+	case schema.List:
+		return "list of " + constraintName(c.Elem)
+	case schema.Set:
+		return "set of " + constraintName(c.Elem)
+	case schema.Map:
+		return "map of " + constraintName(c.Elem)
+	case schema.Tuple:
+		names := make([]string, 0, len(c.Elems))
+		for _, elem := range c.Elems {
+			names = append(names, constraintName(elem))
+		}
+		return "tuple of (" + strings.Join(names, ", ") + ")"
+	case schema.Object:
+		names := make([]string, 0, len(c.Attributes))
+		for _, name := range sortedKeys(c.Attributes) {
+			names = append(names, name+" = "+constraintName(c.Attributes[name].Constraint))
+		}
+		return "object {" + strings.Join(names, ", ") + "}"
+	case schema.OneOf:
+		for _, elem := range c {
+			// see isTypedOneOf
+			if expr, ok := elem.(schema.AnyExpression); ok && expr.OfType != cty.NilType {
+				return typeName(expr.OfType)
+			}
+		}
+		names := make([]string, 0, len(c))
+		for _, elem := range c {
+			names = append(names, constraintName(elem))
+		}
+		return strings.Join(names, " or ")
+	}
+	return cons.FriendlyName()
+}
+
+// typeName returns the type constraint syntax of the given type,
+// which unlike the friendly name includes element and attribute
+// types, so that changes of nested types are detected
+func typeName(t cty.Type) string {
+	if t == cty.NilType {
+		return "any"
+	}
+	return typeexpr.TypeString(t)
+}
+
+func blockNesting(block *schema.BlockSchema) string {
+	switch block.Type {
+	case schema.BlockTypeList:
+		return "list"
+	case schema.BlockTypeSet:
+		return "set"
+	case schema.BlockTypeMap:
+		return "map"
+	case schema.BlockTypeObject:
+		return "object"
+	}
+	return "single"
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// copyPath copies the path, since paths share
+// their backing array while walking the schema
+func copyPath(path []string) []string {
+	return append([]string(nil), path...)
+}
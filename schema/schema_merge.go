@@ -158,67 +158,82 @@ func (m *SchemaMerger) SchemaForModule(meta *tfmod.Meta) (*schema.BodySchema, er
 	}
 
 	for _, module := range declared {
-		depKeys := moduleSourceDependencyKeys(module)
-
-		switch sourceAddr := module.SourceAddr.(type) {
-		case tfaddr.Module:
-			// 1. See if we have a local installation of the module available
-			installedDir, ok := m.stateReader.InstalledModulePath(meta.Path, sourceAddr.String())
-			if ok {
-				path := filepath.Join(meta.Path, installedDir)
-
-				modMeta, err := m.stateReader.LocalModuleMeta(path)
-				if err == nil {
-					depSchema, err := schemaForDependentModuleBlock(module, modMeta)
-					if err == nil {
-						mergedSchema.Blocks["module"].DependentBody[schema.NewSchemaKey(depKeys)] = depSchema
-					}
-
-					// We continue here, so we don't end up overwriting the schema with one from the registry
-					continue
-				}
-			}
+		child, ok := m.resolveChildModule(meta.Path, module)
+		if !ok {
+			continue
+		}
 
-			// 2. See if we have fetched the module schema from the registry
-			modMeta, err := m.stateReader.RegistryModuleMeta(sourceAddr, module.Version)
-			if err != nil {
-				continue
-			}
+		var depSchema *schema.BodySchema
+		var err error
+		if child.meta != nil {
+			depSchema, err = schemaForDependentModuleBlock(module, child.meta)
+		} else {
+			depSchema, err = schemaForDependentRegistryModuleBlock(module, child.registryData)
+		}
+		if err == nil {
+			mergedSchema.Blocks["module"].DependentBody[schema.NewSchemaKey(moduleSourceDependencyKeys(module))] = depSchema
+		}
+	}
 
-			depSchema, err := schemaForDependentRegistryModuleBlock(module, modMeta)
-			if err == nil {
-				mergedSchema.Blocks["module"].DependentBody[schema.NewSchemaKey(depKeys)] = depSchema
-			}
+	return mergedSchema, nil
+}
 
-		case tfmod.RemoteSourceAddr:
-			installedDir, ok := m.stateReader.InstalledModulePath(meta.Path, sourceAddr.String())
-			if !ok {
-				continue
-			}
-			path := filepath.Join(meta.Path, installedDir)
+// childModule represents metadata of a called module, which is either
+// decoded from its local or installed files, or fetched from the registry
+type childModule struct {
+	meta         *tfmod.Meta
+	registryData *registry.ModuleData
+}
 
+// resolveChildModule returns metadata of the module called
+// from the module at the given path by the given module call
+func (m *SchemaMerger) resolveChildModule(modPath string, mc tfmod.DeclaredModuleCall) (childModule, bool) {
+	switch sourceAddr := mc.SourceAddr.(type) {
+	case tfaddr.Module:
+		// 1. See if we have a local installation of the module available
+		installedDir, ok := m.stateReader.InstalledModulePath(modPath, sourceAddr.String())
+		if ok {
+			path := filepath.Join(modPath, installedDir)
+
+			// We don't fall back to the registry if there is an installation,
+			// so we don't end up with metadata of a different version
 			modMeta, err := m.stateReader.LocalModuleMeta(path)
 			if err == nil {
-				depSchema, err := schemaForDependentModuleBlock(module, modMeta)
-				if err == nil {
-					mergedSchema.Blocks["module"].DependentBody[schema.NewSchemaKey(depKeys)] = depSchema
-				}
+				return childModule{meta: modMeta}, true
 			}
+		}
 
-		case tfmod.LocalSourceAddr:
-			path := filepath.Join(meta.Path, sourceAddr.String())
+		// 2. See if we have fetched the module metadata from the registry
+		modData, err := m.stateReader.RegistryModuleMeta(sourceAddr, mc.Version)
+		if err != nil {
+			return childModule{}, false
+		}
+		return childModule{registryData: modData}, true
 
-			modMeta, err := m.stateReader.LocalModuleMeta(path)
-			if err == nil {
-				depSchema, err := schemaForDependentModuleBlock(module, modMeta)
-				if err == nil {
-					mergedSchema.Blocks["module"].DependentBody[schema.NewSchemaKey(depKeys)] = depSchema
-				}
-			}
+	case tfmod.RemoteSourceAddr:
+		installedDir, ok := m.stateReader.InstalledModulePath(modPath, sourceAddr.String())
+		if !ok {
+			return childModule{}, false
 		}
+		path := filepath.Join(modPath, installedDir)
+
+		modMeta, err := m.stateReader.LocalModuleMeta(path)
+		if err != nil {
+			return childModule{}, false
+		}
+		return childModule{meta: modMeta}, true
+
+	case tfmod.LocalSourceAddr:
+		path := filepath.Join(modPath, sourceAddr.String())
+
+		modMeta, err := m.stateReader.LocalModuleMeta(path)
+		if err != nil {
+			return childModule{}, false
+		}
+		return childModule{meta: modMeta}, true
 	}
 
-	return mergedSchema, nil
+	return childModule{}, false
 }
 
 // moduleSourceDependencyKeys helps build a set of dependency keys for module sources.
@@ -0,0 +1,31 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl-lang/schema"
+	test_v1_6 "github.com/opentofu/opentofu-schema/internal/schema/tests/1.6"
+	test_v1_8 "github.com/opentofu/opentofu-schema/internal/schema/tests/1.8"
+)
+
+// CoreTestSchemaForVersion finds a schema for test files
+// (*.tftest.hcl or *.tofutest.hcl) which is relevant for the given
+// OpenTofu version.
+// It will return error if such schema cannot be found, i.e. for versions
+// which predate `tofu test`.
+func CoreTestSchemaForVersion(v *version.Version) (*schema.BodySchema, error) {
+	ver := v.Core()
+
+	if ver.GreaterThanOrEqual(v1_8) {
+		return test_v1_8.TestSchema(ver), nil
+	}
+	if ver.GreaterThanOrEqual(v1_6) {
+		return test_v1_6.TestSchema(ver), nil
+	}
+
+	return nil, NoCompatibleSchemaErr{Version: ver}
+}
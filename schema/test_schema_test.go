@@ -0,0 +1,78 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestCoreTestSchemaForVersion_tooOld(t *testing.T) {
+	v := version.Must(version.NewVersion("1.5.0"))
+	_, err := CoreTestSchemaForVersion(v)
+	if err == nil {
+		t.Fatal("expected error for v1.5")
+	}
+	if !strings.Contains(err.Error(), "no compatible schema") {
+		t.Fatalf("error mismatch: %q", err.Error())
+	}
+}
+
+func TestCoreTestSchemaForVersion_validate(t *testing.T) {
+	versions := []string{
+		"1.6.0",
+		"1.7.0",
+		"1.8.0",
+		"1.12.0",
+	}
+
+	for _, v := range versions {
+		ver, err := version.NewVersion(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodySchema, err := CoreTestSchemaForVersion(ver)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = bodySchema.Validate()
+		if err != nil {
+			t.Fatalf("%s: %s", v, err)
+		}
+	}
+}
+
+func TestCoreTestSchemaForVersion_mocks(t *testing.T) {
+	preMocks := version.Must(version.NewVersion("1.7.0"))
+	bodySchema, err := CoreTestSchemaForVersion(preMocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"mock_provider", "override_resource", "override_data", "override_module"} {
+		if _, ok := bodySchema.Blocks[name]; ok {
+			t.Errorf("did not expect %q block in v1.7 schema", name)
+		}
+	}
+
+	withMocks := version.Must(version.NewVersion("1.8.0"))
+	bodySchema, err = CoreTestSchemaForVersion(withMocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"mock_provider", "override_resource", "override_data", "override_module"} {
+		if _, ok := bodySchema.Blocks[name]; !ok {
+			t.Errorf("expected %q block in v1.8 schema", name)
+		}
+	}
+	for _, name := range []string{"override_resource", "override_data", "override_module"} {
+		if _, ok := bodySchema.Blocks["run"].Body.Blocks[name]; !ok {
+			t.Errorf("expected %q block inside run block in v1.8 schema", name)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-version"
+	"github.com/opentofu/opentofu-schema/schema"
+	tfaddr "github.com/opentofu/registry-address"
+)
+
+// Cache stores encoded provider schemas in a directory, using the layout
+// <hostname>/<namespace>/<type>/<version>.schema
+type Cache struct {
+	dir string
+}
+
+// NewCache returns a cache of provider schemas in the given directory.
+// The directory is created on first write.
+func NewCache(dir string) *Cache {
+	return &Cache{
+		dir: dir,
+	}
+}
+
+// Get returns the cached schema of the given provider version
+// or NotCachedErr if there is none
+func (c *Cache) Get(pAddr tfaddr.Provider, v *version.Version) (*schema.ProviderSchema, error) {
+	b, err := os.ReadFile(c.path(pAddr, v))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, NotCachedErr{Addr: pAddr, Version: v}
+		}
+		return nil, err
+	}
+
+	ps, err := Unmarshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", pAddr, v, err)
+	}
+	return ps, nil
+}
+
+// Put stores the schema of the given provider version,
+// replacing any schema previously cached for it
+func (c *Cache) Put(pAddr tfaddr.Provider, v *version.Version, ps *schema.ProviderSchema) error {
+	b, err := Marshal(ps)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", pAddr, v, err)
+	}
+
+	path := c.path(pAddr, v)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	// The schema is written to a temporary file first, so that
+	// concurrent readers never observe a partially written one
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+// Remove removes the cached schema of the given provider version, if any
+func (c *Cache) Remove(pAddr tfaddr.Provider, v *version.Version) error {
+	err := os.Remove(c.path(pAddr, v))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (c *Cache) path(pAddr tfaddr.Provider, v *version.Version) string {
+	return filepath.Join(c.dir, pAddr.Hostname.ForDisplay(), pAddr.Namespace, pAddr.Type, v.String()+".schema")
+}
+
+type NotCachedErr struct {
+	Addr    tfaddr.Provider
+	Version *version.Version
+}
+
+func (e NotCachedErr) Error() string {
+	return fmt.Sprintf("schema of provider %s %s not cached", e.Addr, e.Version)
+}
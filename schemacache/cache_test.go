@@ -0,0 +1,66 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/opentofu/opentofu-schema/internal/addr"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+)
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir)
+	pAddr := addr.NewDefaultProvider("stub")
+	v := version.Must(version.NewVersion("1.2.0"))
+
+	_, err := cache.Get(pAddr, v)
+	var notCached NotCachedErr
+	if !errors.As(err, &notCached) {
+		t.Fatalf("expected NotCachedErr, given %#v", err)
+	}
+
+	ps := testProviderSchema(t)
+	if err := cache.Put(pAddr, v, ps); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPath := filepath.Join(dir, "registry.opentofu.org", "hashicorp", "stub", "1.2.0.schema")
+	if _, err := os.Stat(expectedPath); err != nil {
+		t.Fatalf("expected schema at %s: %s", expectedPath, err)
+	}
+
+	cached, err := cache.Get(pAddr, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(ps, cached, ctydebug.CmpOptions); diff != "" {
+		t.Fatalf("provider schema mismatch: %s", diff)
+	}
+
+	// Other versions are cached separately
+	_, err = cache.Get(pAddr, version.Must(version.NewVersion("1.3.0")))
+	if !errors.As(err, &notCached) {
+		t.Fatalf("expected NotCachedErr, given %#v", err)
+	}
+
+	if err := cache.Remove(pAddr, v); err != nil {
+		t.Fatal(err)
+	}
+	_, err = cache.Get(pAddr, v)
+	if !errors.As(err, &notCached) {
+		t.Fatalf("expected NotCachedErr after removal, given %#v", err)
+	}
+	if err := cache.Remove(pAddr, v); err != nil {
+		t.Fatalf("expected no error removing uncached schema, given %s", err)
+	}
+}
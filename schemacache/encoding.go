@@ -0,0 +1,860 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package schemacache encodes provider schemas for storage on disk,
+// so that they do not have to be rebuilt on every start
+package schemacache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/hcl-lang/lang"
+	"github.com/hashicorp/hcl-lang/schema"
+	tfschema "github.com/opentofu/opentofu-schema/schema"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// FormatVersion is the version of the encoding produced by Marshal.
+// It must be bumped whenever the encoding changes incompatibly.
+const FormatVersion = 1
+
+// Marshal encodes the given provider schema as gzip-compressed JSON.
+//
+// Only the parts of hcl-lang schema which are produced from provider
+// schemas (see tfschema.ProviderSchemaFromJson) can be encoded, which
+// makes Unmarshal return an exact copy. Any other part, e.g. a reference
+// constraint or a dependent body, results in an error.
+func Marshal(ps *tfschema.ProviderSchema) ([]byte, error) {
+	eps, err := encodeProviderSchema(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(eps); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes a provider schema encoded by Marshal
+func Unmarshal(b []byte) (*tfschema.ProviderSchema, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider schema encoding: %w", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider schema encoding: %w", err)
+	}
+
+	// The version is decoded first, since the rest
+	// of the encoding may differ between versions
+	var header struct {
+		FormatVersion int `json:"v"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid provider schema encoding: %w", err)
+	}
+	if header.FormatVersion != FormatVersion {
+		return nil, UnsupportedFormatErr{Version: header.FormatVersion}
+	}
+
+	var eps encodedProviderSchema
+	if err := json.Unmarshal(data, &eps); err != nil {
+		return nil, fmt.Errorf("invalid provider schema encoding: %w", err)
+	}
+
+	return eps.decode()
+}
+
+type UnsupportedFormatErr struct {
+	Version int
+}
+
+func (e UnsupportedFormatErr) Error() string {
+	return fmt.Sprintf("unsupported provider schema format version %d (expected %d)", e.Version, FormatVersion)
+}
+
+// The encoded types below use short JSON keys to keep the encoding
+// compact. Maps and slices are never omitted, so that nil and empty
+// collections are told apart when decoding.
+
+type encodedProviderSchema struct {
+	FormatVersion      int                          `json:"v"`
+	Provider           *encodedBody                 `json:"p"`
+	Resources          map[string]*encodedBody      `json:"r"`
+	EphemeralResources map[string]*encodedBody      `json:"e"`
+	DataSources        map[string]*encodedBody      `json:"d"`
+	Functions          map[string]*encodedSignature `json:"f"`
+}
+
+type encodedBody struct {
+	Blocks       map[string]*encodedBlock     `json:"b"`
+	Attributes   map[string]*encodedAttribute `json:"a"`
+	AnyAttribute *encodedAttribute            `json:"aa,omitempty"`
+	IsDeprecated bool                         `json:"dep,omitempty"`
+	Detail       string                       `json:"det,omitempty"`
+	Description  *encodedMarkup               `json:"desc,omitempty"`
+	HoverURL     string                       `json:"url,omitempty"`
+	DocsLink     *schema.DocsLink             `json:"docs,omitempty"`
+}
+
+type encodedBlock struct {
+	Type         schema.BlockType `json:"t,omitempty"`
+	Labels       []*encodedLabel  `json:"l"`
+	Description  *encodedMarkup   `json:"desc,omitempty"`
+	IsDeprecated bool             `json:"dep,omitempty"`
+	MinItems     uint64           `json:"min,omitempty"`
+	MaxItems     uint64           `json:"max,omitempty"`
+	Body         *encodedBody     `json:"body"`
+}
+
+type encodedLabel struct {
+	Name        string         `json:"n"`
+	Description *encodedMarkup `json:"desc,omitempty"`
+	IsDepKey    bool           `json:"dk,omitempty"`
+	Completable bool           `json:"c,omitempty"`
+}
+
+type encodedAttribute struct {
+	Description  *encodedMarkup     `json:"desc,omitempty"`
+	IsRequired   bool               `json:"req,omitempty"`
+	IsOptional   bool               `json:"opt,omitempty"`
+	IsDeprecated bool               `json:"dep,omitempty"`
+	IsComputed   bool               `json:"comp,omitempty"`
+	IsSensitive  bool               `json:"sens,omitempty"`
+	Constraint   *encodedConstraint `json:"c,omitempty"`
+}
+
+type encodedMarkup struct {
+	Value string          `json:"v"`
+	Kind  lang.MarkupKind `json:"k"`
+}
+
+// encodedConstraint represents any of the supported constraints,
+// distinguished by their kind
+type encodedConstraint struct {
+	Kind string `json:"k"`
+
+	Type             *cty.Type                    `json:"t,omitempty"`
+	SkipComplexTypes bool                         `json:"s,omitempty"`
+	Keyword          string                       `json:"kw,omitempty"`
+	Name             string                       `json:"n,omitempty"`
+	Description      *encodedMarkup               `json:"desc,omitempty"`
+	Elem             *encodedConstraint           `json:"el,omitempty"`
+	Elems            []*encodedConstraint         `json:"els"`
+	MinItems         uint64                       `json:"min,omitempty"`
+	MaxItems         uint64                       `json:"max,omitempty"`
+	AllowInterpKeys  bool                         `json:"ik,omitempty"`
+	Attributes       map[string]*encodedAttribute `json:"a"`
+}
+
+const (
+	constraintAnyExpression = "any"
+	constraintLiteralType   = "lit"
+	constraintKeyword       = "kw"
+	constraintOneOf         = "one"
+	constraintList          = "list"
+	constraintSet           = "set"
+	constraintMap           = "map"
+	constraintObject        = "obj"
+	constraintTuple         = "tuple"
+)
+
+type encodedSignature struct {
+	Description string              `json:"desc,omitempty"`
+	Detail      string              `json:"det,omitempty"`
+	ReturnType  *cty.Type           `json:"ret,omitempty"`
+	Params      []*encodedParameter `json:"p"`
+	VarParam    *encodedParameter   `json:"vp,omitempty"`
+}
+
+type encodedParameter struct {
+	Name             string    `json:"n"`
+	Description      string    `json:"desc,omitempty"`
+	Type             *cty.Type `json:"t,omitempty"`
+	AllowNull        bool      `json:"null,omitempty"`
+	AllowUnknown     bool      `json:"unk,omitempty"`
+	AllowDynamicType bool      `json:"dyn,omitempty"`
+	AllowMarked      bool      `json:"mark,omitempty"`
+}
+
+type unsupportedErr struct {
+	What string
+}
+
+func (e unsupportedErr) Error() string {
+	return fmt.Sprintf("unable to encode %s", e.What)
+}
+
+func encodeProviderSchema(ps *tfschema.ProviderSchema) (*encodedProviderSchema, error) {
+	if ps == nil {
+		return nil, unsupportedErr{What: "nil provider schema"}
+	}
+
+	eps := &encodedProviderSchema{
+		FormatVersion: FormatVersion,
+	}
+
+	var err error
+	eps.Provider, err = encodeBody(ps.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("provider: %w", err)
+	}
+	eps.Resources, err = encodeBodies(ps.Resources)
+	if err != nil {
+		return nil, fmt.Errorf("resources: %w", err)
+	}
+	eps.EphemeralResources, err = encodeBodies(ps.EphemeralResources)
+	if err != nil {
+		return nil, fmt.Errorf("ephemeral resources: %w", err)
+	}
+	eps.DataSources, err = encodeBodies(ps.DataSources)
+	if err != nil {
+		return nil, fmt.Errorf("data sources: %w", err)
+	}
+
+	if ps.Functions != nil {
+		eps.Functions = make(map[string]*encodedSignature, len(ps.Functions))
+		for name, sig := range ps.Functions {
+			eps.Functions[name] = encodeSignature(sig)
+		}
+	}
+
+	return eps, nil
+}
+
+func encodeBodies(bodies map[string]*schema.BodySchema) (map[string]*encodedBody, error) {
+	if bodies == nil {
+		return nil, nil
+	}
+	encoded := make(map[string]*encodedBody, len(bodies))
+	for name, body := range bodies {
+		eb, err := encodeBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", name, err)
+		}
+		encoded[name] = eb
+	}
+	return encoded, nil
+}
+
+func encodeBody(body *schema.BodySchema) (*encodedBody, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	if body.Extensions != nil || body.Targets != nil || len(body.ImpliedOrigins) > 0 || len(body.TargetableAs) > 0 {
+		return nil, unsupportedErr{What: "body with extensions or references"}
+	}
+
+	eb := &encodedBody{
+		IsDeprecated: body.IsDeprecated,
+		Detail:       body.Detail,
+		Description:  encodeMarkup(body.Description),
+		HoverURL:     body.HoverURL,
+		DocsLink:     body.DocsLink,
+	}
+
+	if body.Blocks != nil {
+		eb.Blocks = make(map[string]*encodedBlock, len(body.Blocks))
+		for name, block := range body.Blocks {
+			encodedBlock, err := encodeBlock(block)
+			if err != nil {
+				return nil, fmt.Errorf("block %q: %w", name, err)
+			}
+			eb.Blocks[name] = encodedBlock
+		}
+	}
+
+	var err error
+	eb.Attributes, err = encodeAttributes(body.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if body.AnyAttribute != nil {
+		eb.AnyAttribute, err = encodeAttribute(body.AnyAttribute)
+		if err != nil {
+			return nil, fmt.Errorf("any attribute: %w", err)
+		}
+	}
+
+	return eb, nil
+}
+
+func encodeBlock(block *schema.BlockSchema) (*encodedBlock, error) {
+	if block == nil {
+		return nil, nil
+	}
+
+	if block.Address != nil || block.DependentBody != nil {
+		return nil, unsupportedErr{What: "block with address or dependent body"}
+	}
+
+	eb := &encodedBlock{
+		Type:         block.Type,
+		Description:  encodeMarkup(block.Description),
+		IsDeprecated: block.IsDeprecated,
+		MinItems:     block.MinItems,
+		MaxItems:     block.MaxItems,
+	}
+
+	if block.Labels != nil {
+		eb.Labels = make([]*encodedLabel, 0, len(block.Labels))
+		for _, label := range block.Labels {
+			eb.Labels = append(eb.Labels, &encodedLabel{
+				Name:        label.Name,
+				Description: encodeMarkup(label.Description),
+				IsDepKey:    label.IsDepKey,
+				Completable: label.Completable,
+			})
+		}
+	}
+
+	var err error
+	eb.Body, err = encodeBody(block.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return eb, nil
+}
+
+func encodeAttributes(attributes map[string]*schema.AttributeSchema) (map[string]*encodedAttribute, error) {
+	if attributes == nil {
+		return nil, nil
+	}
+	encoded := make(map[string]*encodedAttribute, len(attributes))
+	for name, attr := range attributes {
+		ea, err := encodeAttribute(attr)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+		encoded[name] = ea
+	}
+	return encoded, nil
+}
+
+func encodeAttribute(attr *schema.AttributeSchema) (*encodedAttribute, error) {
+	if attr == nil {
+		return nil, nil
+	}
+
+	if attr.DefaultValue != nil || attr.IsDepKey || attr.Address != nil || attr.OriginForTarget != nil {
+		return nil, unsupportedErr{What: "attribute with default value or references"}
+	}
+
+	constraint, err := encodeConstraint(attr.Constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encodedAttribute{
+		Description:  encodeMarkup(attr.Description),
+		IsRequired:   attr.IsRequired,
+		IsOptional:   attr.IsOptional,
+		IsDeprecated: attr.IsDeprecated,
+		IsComputed:   attr.IsComputed,
+		IsSensitive:  attr.IsSensitive,
+		Constraint:   constraint,
+	}, nil
+}
+
+func encodeConstraint(cons schema.Constraint) (*encodedConstraint, error) {
+	switch c := cons.(type) {
+	case nil:
+		return nil, nil
+	case schema.AnyExpression:
+		return &encodedConstraint{
+			Kind:             constraintAnyExpression,
+			Type:             encodeType(c.OfType),
+			SkipComplexTypes: c.SkipLiteralComplexTypes,
+		}, nil
+	case schema.LiteralType:
+		return &encodedConstraint{
+			Kind:             constraintLiteralType,
+			Type:             encodeType(c.Type),
+			SkipComplexTypes: c.SkipComplexTypes,
+		}, nil
+	case schema.Keyword:
+		return &encodedConstraint{
+			Kind:        constraintKeyword,
+			Keyword:     c.Keyword,
+			Name:        c.Name,
+			Description: encodeMarkup(c.Description),
+		}, nil
+	case schema.OneOf:
+		elems, err := encodeConstraints(c)
+		if err != nil {
+			return nil, err
+		}
+		return &encodedConstraint{
+			Kind:  constraintOneOf,
+			Elems: elems,
+		}, nil
+	case schema.List:
+		elem, err := encodeConstraint(c.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return &encodedConstraint{
+			Kind:        constraintList,
+			Elem:        elem,
+			Description: encodeMarkup(c.Description),
+			MinItems:    c.MinItems,
+			MaxItems:    c.MaxItems,
+		}, nil
+	case schema.Set:
+		elem, err := encodeConstraint(c.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return &encodedConstraint{
+			Kind:        constraintSet,
+			Elem:        elem,
+			Description: encodeMarkup(c.Description),
+			MinItems:    c.MinItems,
+			MaxItems:    c.MaxItems,
+		}, nil
+	case schema.Map:
+		elem, err := encodeConstraint(c.Elem)
+		if err != nil {
+			return nil, err
+		}
+		return &encodedConstraint{
+			Kind:            constraintMap,
+			Elem:            elem,
+			Name:            c.Name,
+			Description:     encodeMarkup(c.Description),
+			MinItems:        c.MinItems,
+			MaxItems:        c.MaxItems,
+			AllowInterpKeys: c.AllowInterpolatedKeys,
+		}, nil
+	case schema.Object:
+		attributes, err := encodeAttributes(c.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return &encodedConstraint{
+			Kind:            constraintObject,
+			Attributes:      attributes,
+			Name:            c.Name,
+			Description:     encodeMarkup(c.Description),
+			AllowInterpKeys: c.AllowInterpolatedKeys,
+		}, nil
+	case schema.Tuple:
+		elems, err := encodeConstraints(c.Elems)
+		if err != nil {
+			return nil, err
+		}
+		return &encodedConstraint{
+			Kind:        constraintTuple,
+			Elems:       elems,
+			Description: encodeMarkup(c.Description),
+		}, nil
+	}
+
+	return nil, unsupportedErr{What: fmt.Sprintf("constraint %T", cons)}
+}
+
+func encodeConstraints(constraints []schema.Constraint) ([]*encodedConstraint, error) {
+	if constraints == nil {
+		return nil, nil
+	}
+	encoded := make([]*encodedConstraint, 0, len(constraints))
+	for _, cons := range constraints {
+		ec, err := encodeConstraint(cons)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, ec)
+	}
+	return encoded, nil
+}
+
+func encodeSignature(sig *schema.FunctionSignature) *encodedSignature {
+	if sig == nil {
+		return nil
+	}
+
+	es := &encodedSignature{
+		Description: sig.Description,
+		Detail:      sig.Detail,
+		ReturnType:  encodeType(sig.ReturnType),
+		VarParam:    encodeParameter(sig.VarParam),
+	}
+	if sig.Params != nil {
+		es.Params = make([]*encodedParameter, 0, len(sig.Params))
+		for i := range sig.Params {
+			es.Params = append(es.Params, encodeParameter(&sig.Params[i]))
+		}
+	}
+	return es
+}
+
+func encodeParameter(param *function.Parameter) *encodedParameter {
+	if param == nil {
+		return nil
+	}
+	return &encodedParameter{
+		Name:             param.Name,
+		Description:      param.Description,
+		Type:             encodeType(param.Type),
+		AllowNull:        param.AllowNull,
+		AllowUnknown:     param.AllowUnknown,
+		AllowDynamicType: param.AllowDynamicType,
+		AllowMarked:      param.AllowMarked,
+	}
+}
+
+func encodeMarkup(mc lang.MarkupContent) *encodedMarkup {
+	if mc == (lang.MarkupContent{}) {
+		return nil
+	}
+	return &encodedMarkup{
+		Value: mc.Value,
+		Kind:  mc.Kind,
+	}
+}
+
+// encodeType returns nil for cty.NilType, which cannot be encoded as JSON
+func encodeType(t cty.Type) *cty.Type {
+	if t == cty.NilType {
+		return nil
+	}
+	return &t
+}
+
+func (eps *encodedProviderSchema) decode() (*tfschema.ProviderSchema, error) {
+	ps := &tfschema.ProviderSchema{
+		Provider:           eps.Provider.decode(),
+		Resources:          decodeBodies(eps.Resources),
+		EphemeralResources: decodeBodies(eps.EphemeralResources),
+		DataSources:        decodeBodies(eps.DataSources),
+	}
+
+	if eps.Functions != nil {
+		ps.Functions = make(map[string]*schema.FunctionSignature, len(eps.Functions))
+		for name, es := range eps.Functions {
+			sig, err := es.decode()
+			if err != nil {
+				return nil, fmt.Errorf("invalid provider schema encoding: function %q: %w", name, err)
+			}
+			ps.Functions[name] = sig
+		}
+	}
+
+	return ps, validateConstraints(eps)
+}
+
+// validateConstraints checks that all constraints are of a known kind,
+// which may not be the case for an encoding of a newer version
+// written without bumping FormatVersion
+func validateConstraints(eps *encodedProviderSchema) error {
+	var walkBody func(eb *encodedBody) error
+	var walkAttributes func(attrs map[string]*encodedAttribute) error
+	var walkConstraint func(ec *encodedConstraint) error
+
+	walkConstraint = func(ec *encodedConstraint) error {
+		if ec == nil {
+			return nil
+		}
+		switch ec.Kind {
+		case constraintAnyExpression, constraintLiteralType, constraintKeyword,
+			constraintOneOf, constraintList, constraintSet, constraintMap,
+			constraintObject, constraintTuple:
+		default:
+			return fmt.Errorf("invalid provider schema encoding: unknown constraint %q", ec.Kind)
+		}
+		if err := walkConstraint(ec.Elem); err != nil {
+			return err
+		}
+		for _, elem := range ec.Elems {
+			if err := walkConstraint(elem); err != nil {
+				return err
+			}
+		}
+		return walkAttributes(ec.Attributes)
+	}
+	walkAttributes = func(attrs map[string]*encodedAttribute) error {
+		for _, attr := range attrs {
+			if attr == nil {
+				continue
+			}
+			if err := walkConstraint(attr.Constraint); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	walkBody = func(eb *encodedBody) error {
+		if eb == nil {
+			return nil
+		}
+		if err := walkAttributes(eb.Attributes); err != nil {
+			return err
+		}
+		if eb.AnyAttribute != nil {
+			if err := walkConstraint(eb.AnyAttribute.Constraint); err != nil {
+				return err
+			}
+		}
+		for _, block := range eb.Blocks {
+			if block == nil {
+				continue
+			}
+			if err := walkBody(block.Body); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walkBody(eps.Provider); err != nil {
+		return err
+	}
+	for _, bodies := range []map[string]*encodedBody{eps.Resources, eps.EphemeralResources, eps.DataSources} {
+		for _, eb := range bodies {
+			if err := walkBody(eb); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func decodeBodies(encoded map[string]*encodedBody) map[string]*schema.BodySchema {
+	if encoded == nil {
+		return nil
+	}
+	bodies := make(map[string]*schema.BodySchema, len(encoded))
+	for name, eb := range encoded {
+		bodies[name] = eb.decode()
+	}
+	return bodies
+}
+
+func (eb *encodedBody) decode() *schema.BodySchema {
+	if eb == nil {
+		return nil
+	}
+
+	body := &schema.BodySchema{
+		Attributes:   decodeAttributes(eb.Attributes),
+		AnyAttribute: eb.AnyAttribute.decode(),
+		IsDeprecated: eb.IsDeprecated,
+		Detail:       eb.Detail,
+		Description:  eb.Description.decode(),
+		HoverURL:     eb.HoverURL,
+		DocsLink:     eb.DocsLink,
+	}
+
+	if eb.Blocks != nil {
+		body.Blocks = make(map[string]*schema.BlockSchema, len(eb.Blocks))
+		for name, block := range eb.Blocks {
+			body.Blocks[name] = block.decode()
+		}
+	}
+
+	return body
+}
+
+func (eb *encodedBlock) decode() *schema.BlockSchema {
+	if eb == nil {
+		return nil
+	}
+
+	block := &schema.BlockSchema{
+		Type:         eb.Type,
+		Description:  eb.Description.decode(),
+		IsDeprecated: eb.IsDeprecated,
+		MinItems:     eb.MinItems,
+		MaxItems:     eb.MaxItems,
+		Body:         eb.Body.decode(),
+	}
+
+	if eb.Labels != nil {
+		block.Labels = make([]*schema.LabelSchema, 0, len(eb.Labels))
+		for _, label := range eb.Labels {
+			block.Labels = append(block.Labels, &schema.LabelSchema{
+				Name:        label.Name,
+				Description: label.Description.decode(),
+				IsDepKey:    label.IsDepKey,
+				Completable: label.Completable,
+			})
+		}
+	}
+
+	return block
+}
+
+func decodeAttributes(encoded map[string]*encodedAttribute) map[string]*schema.AttributeSchema {
+	if encoded == nil {
+		return nil
+	}
+	attributes := make(map[string]*schema.AttributeSchema, len(encoded))
+	for name, ea := range encoded {
+		attributes[name] = ea.decode()
+	}
+	return attributes
+}
+
+func (ea *encodedAttribute) decode() *schema.AttributeSchema {
+	if ea == nil {
+		return nil
+	}
+	return &schema.AttributeSchema{
+		Description:  ea.Description.decode(),
+		IsRequired:   ea.IsRequired,
+		IsOptional:   ea.IsOptional,
+		IsDeprecated: ea.IsDeprecated,
+		IsComputed:   ea.IsComputed,
+		IsSensitive:  ea.IsSensitive,
+		Constraint:   ea.Constraint.decode(),
+	}
+}
+
+func (ec *encodedConstraint) decode() schema.Constraint {
+	if ec == nil {
+		return nil
+	}
+
+	switch ec.Kind {
+	case constraintAnyExpression:
+		return schema.AnyExpression{
+			OfType:                  decodeType(ec.Type),
+			SkipLiteralComplexTypes: ec.SkipComplexTypes,
+		}
+	case constraintLiteralType:
+		return schema.LiteralType{
+			Type:             decodeType(ec.Type),
+			SkipComplexTypes: ec.SkipComplexTypes,
+		}
+	case constraintKeyword:
+		return schema.Keyword{
+			Keyword:     ec.Keyword,
+			Name:        ec.Name,
+			Description: ec.Description.decode(),
+		}
+	case constraintOneOf:
+		return schema.OneOf(decodeConstraints(ec.Elems))
+	case constraintList:
+		return schema.List{
+			Elem:        ec.Elem.decode(),
+			Description: ec.Description.decode(),
+			MinItems:    ec.MinItems,
+			MaxItems:    ec.MaxItems,
+		}
+	case constraintSet:
+		return schema.Set{
+			Elem:        ec.Elem.decode(),
+			Description: ec.Description.decode(),
+			MinItems:    ec.MinItems,
+			MaxItems:    ec.MaxItems,
+		}
+	case constraintMap:
+		return schema.Map{
+			Elem:                  ec.Elem.decode(),
+			Name:                  ec.Name,
+			Description:           ec.Description.decode(),
+			MinItems:              ec.MinItems,
+			MaxItems:              ec.MaxItems,
+			AllowInterpolatedKeys: ec.AllowInterpKeys,
+		}
+	case constraintObject:
+		return schema.Object{
+			Attributes:            decodeAttributes(ec.Attributes),
+			Name:                  ec.Name,
+			Description:           ec.Description.decode(),
+			AllowInterpolatedKeys: ec.AllowInterpKeys,
+		}
+	case constraintTuple:
+		return schema.Tuple{
+			Elems:       decodeConstraints(ec.Elems),
+			Description: ec.Description.decode(),
+		}
+	}
+
+	// Unknown kinds are reported by validateConstraints
+	return nil
+}
+
+func decodeConstraints(encoded []*encodedConstraint) []schema.Constraint {
+	if encoded == nil {
+		return nil
+	}
+	constraints := make([]schema.Constraint, 0, len(encoded))
+	for _, ec := range encoded {
+		constraints = append(constraints, ec.decode())
+	}
+	return constraints
+}
+
+func (es *encodedSignature) decode() (*schema.FunctionSignature, error) {
+	if es == nil {
+		return nil, nil
+	}
+
+	sig := &schema.FunctionSignature{
+		Description: es.Description,
+		Detail:      es.Detail,
+		ReturnType:  decodeType(es.ReturnType),
+		VarParam:    es.VarParam.decode(),
+	}
+	if es.Params != nil {
+		sig.Params = make([]function.Parameter, 0, len(es.Params))
+		for i, param := range es.Params {
+			if param == nil {
+				return nil, fmt.Errorf("missing parameter %d", i)
+			}
+			sig.Params = append(sig.Params, *param.decode())
+		}
+	}
+	return sig, nil
+}
+
+func (ep *encodedParameter) decode() *function.Parameter {
+	if ep == nil {
+		return nil
+	}
+	return &function.Parameter{
+		Name:             ep.Name,
+		Description:      ep.Description,
+		Type:             decodeType(ep.Type),
+		AllowNull:        ep.AllowNull,
+		AllowUnknown:     ep.AllowUnknown,
+		AllowDynamicType: ep.AllowDynamicType,
+		AllowMarked:      ep.AllowMarked,
+	}
+}
+
+func (em *encodedMarkup) decode() lang.MarkupContent {
+	if em == nil {
+		return lang.MarkupContent{}
+	}
+	return lang.MarkupContent{
+		Value: em.Value,
+		Kind:  em.Kind,
+	}
+}
+
+func decodeType(t *cty.Type) cty.Type {
+	if t == nil {
+		return cty.NilType
+	}
+	return *t
+}
@@ -0,0 +1,270 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schemacache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl-lang/schema"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/opentofu/opentofu-schema/internal/addr"
+	tfschema "github.com/opentofu/opentofu-schema/schema"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+)
+
+var testProviderSchemaJSON = `{
+	"provider": {
+		"block": {
+			"attributes": {
+				"region": {
+					"type": "string",
+					"description": "The **region** to use",
+					"description_kind": "markdown",
+					"required": true
+				}
+			}
+		}
+	},
+	"resource_schemas": {
+		"stub_instance": {
+			"version": 1,
+			"block": {
+				"attributes": {
+					"name": {
+						"type": "string",
+						"required": true
+					},
+					"labels": {
+						"type": ["map", "string"],
+						"optional": true
+					},
+					"ports": {
+						"type": ["list", ["tuple", ["number", "string"]]],
+						"optional": true,
+						"deprecated": true
+					},
+					"tags": {
+						"nested_type": {
+							"nesting_mode": "set",
+							"attributes": {
+								"key": {
+									"type": "string",
+									"required": true
+								},
+								"value": {
+									"type": "string",
+									"optional": true,
+									"sensitive": true
+								}
+							}
+						},
+						"optional": true
+					},
+					"metadata": {
+						"type": "dynamic",
+						"computed": true
+					},
+					"coordinates": {
+						"type": ["tuple", []],
+						"optional": true
+					},
+					"settings": {
+						"type": ["object", {}],
+						"optional": true
+					},
+					"options": {
+						"nested_type": {
+							"nesting_mode": "single",
+							"attributes": {}
+						},
+						"optional": true
+					}
+				},
+				"block_types": {
+					"network": {
+						"nesting_mode": "list",
+						"min_items": 1,
+						"max_items": 2,
+						"block": {
+							"attributes": {
+								"subnet_id": {
+									"type": "string",
+									"description": "Subnet to attach to",
+									"description_kind": "plain",
+									"optional": true
+								}
+							}
+						}
+					},
+					"disk": {
+						"nesting_mode": "map",
+						"block": {}
+					}
+				},
+				"description": "An instance",
+				"description_kind": "plain"
+			}
+		}
+	},
+	"ephemeral_resource_schemas": {
+		"stub_token": {
+			"block": {
+				"attributes": {
+					"value": {
+						"type": "string",
+						"computed": true,
+						"sensitive": true
+					}
+				}
+			}
+		}
+	},
+	"data_source_schemas": {
+		"stub_image": {
+			"block": {
+				"attributes": {
+					"filter": {
+						"type": ["object", {"name": "string", "values": ["set", "string"]}],
+						"optional": true
+					}
+				}
+			}
+		}
+	},
+	"functions": {
+		"parse_id": {
+			"description": "Parses an instance ID",
+			"summary": "Parses an ID",
+			"return_type": ["object", {"region": "string", "id": "string"}],
+			"parameters": [
+				{
+					"name": "id",
+					"type": "string",
+					"description": "The instance ID",
+					"is_nullable": true
+				}
+			],
+			"variadic_parameter": {
+				"name": "parts",
+				"type": "dynamic"
+			}
+		},
+		"noop": {
+			"return_type": "bool"
+		}
+	}
+}`
+
+func testProviderSchema(t *testing.T) *tfschema.ProviderSchema {
+	var jsonSchema tfjson.ProviderSchema
+	if err := json.Unmarshal([]byte(testProviderSchemaJSON), &jsonSchema); err != nil {
+		t.Fatal(err)
+	}
+	return tfschema.ProviderSchemaFromJson(&jsonSchema, addr.NewDefaultProvider("stub"))
+}
+
+func TestMarshal_roundTrip(t *testing.T) {
+	testCases := map[string]*tfschema.ProviderSchema{
+		"empty":    {},
+		"provider": testProviderSchema(t),
+		"constraints": {
+			Resources: map[string]*schema.BodySchema{
+				"stub_resource": {
+					Attributes: map[string]*schema.AttributeSchema{
+						"mode": {
+							IsOptional: true,
+							Constraint: schema.OneOf{
+								schema.Keyword{Keyword: "auto", Name: "mode"},
+								schema.LiteralType{SkipComplexTypes: true},
+							},
+						},
+					},
+					AnyAttribute: &schema.AttributeSchema{
+						Constraint: schema.AnyExpression{SkipLiteralComplexTypes: true},
+					},
+				},
+			},
+		},
+	}
+
+	for name, ps := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b, err := Marshal(ps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := Unmarshal(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(ps, decoded, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("provider schema mismatch: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshal_unsupported(t *testing.T) {
+	ps := &tfschema.ProviderSchema{
+		Provider: &schema.BodySchema{
+			Attributes: map[string]*schema.AttributeSchema{
+				"ref": {
+					Constraint: schema.Reference{OfScopeId: "foo"},
+				},
+			},
+		},
+	}
+
+	_, err := Marshal(ps)
+	var unsupported unsupportedErr
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected unsupportedErr, given %#v", err)
+	}
+}
+
+func TestUnmarshal_unsupportedVersion(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(`{"v": 999}`))
+	zw.Close()
+
+	_, err := Unmarshal(buf.Bytes())
+	var unsupported UnsupportedFormatErr
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("expected UnsupportedFormatErr, given %#v", err)
+	}
+	if unsupported.Version != 999 {
+		t.Fatalf("expected version 999, given %d", unsupported.Version)
+	}
+}
+
+func TestUnmarshal_invalid(t *testing.T) {
+	_, err := Unmarshal([]byte("not gzip"))
+	if err == nil {
+		t.Fatal("expected error for invalid encoding")
+	}
+}
+
+func TestUnmarshal_nullParameter(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(`{"v": 1, "f": {"parse_id": {"p": [null]}}}`))
+	zw.Close()
+
+	_, err := Unmarshal(buf.Bytes())
+	if err == nil {
+		t.Fatal("expected error for null parameter")
+	}
+	if !strings.Contains(err.Error(), "invalid provider schema encoding") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package staticeval
+
+import (
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// pureFunctions is a subset of OpenTofu functions which have no side effects
+// and do not depend on the filesystem or the time, so it is safe to call
+// them when evaluating expressions statically
+var pureFunctions = map[string]function.Function{
+	"abs":             stdlib.AbsoluteFunc,
+	"ceil":            stdlib.CeilFunc,
+	"chomp":           stdlib.ChompFunc,
+	"chunklist":       stdlib.ChunklistFunc,
+	"coalesce":        stdlib.CoalesceFunc,
+	"coalescelist":    stdlib.CoalesceListFunc,
+	"compact":         stdlib.CompactFunc,
+	"concat":          stdlib.ConcatFunc,
+	"contains":        stdlib.ContainsFunc,
+	"csvdecode":       stdlib.CSVDecodeFunc,
+	"distinct":        stdlib.DistinctFunc,
+	"element":         stdlib.ElementFunc,
+	"endswith":        endsWithFunc,
+	"flatten":         stdlib.FlattenFunc,
+	"floor":           stdlib.FloorFunc,
+	"format":          stdlib.FormatFunc,
+	"formatlist":      stdlib.FormatListFunc,
+	"indent":          stdlib.IndentFunc,
+	"index":           stdlib.IndexFunc,
+	"join":            stdlib.JoinFunc,
+	"jsondecode":      stdlib.JSONDecodeFunc,
+	"jsonencode":      stdlib.JSONEncodeFunc,
+	"keys":            stdlib.KeysFunc,
+	"length":          lengthFunc,
+	"log":             stdlib.LogFunc,
+	"lookup":          stdlib.LookupFunc,
+	"lower":           stdlib.LowerFunc,
+	"max":             stdlib.MaxFunc,
+	"merge":           stdlib.MergeFunc,
+	"min":             stdlib.MinFunc,
+	"parseint":        stdlib.ParseIntFunc,
+	"pow":             stdlib.PowFunc,
+	"range":           stdlib.RangeFunc,
+	"regex":           stdlib.RegexFunc,
+	"regexall":        stdlib.RegexAllFunc,
+	"replace":         stdlib.ReplaceFunc,
+	"reverse":         stdlib.ReverseListFunc,
+	"setintersection": stdlib.SetIntersectionFunc,
+	"setproduct":      stdlib.SetProductFunc,
+	"setsubtract":     stdlib.SetSubtractFunc,
+	"setunion":        stdlib.SetUnionFunc,
+	"signum":          stdlib.SignumFunc,
+	"slice":           stdlib.SliceFunc,
+	"sort":            stdlib.SortFunc,
+	"split":           stdlib.SplitFunc,
+	"startswith":      startsWithFunc,
+	"strcontains":     strContainsFunc,
+	"strrev":          stdlib.ReverseFunc,
+	"substr":          stdlib.SubstrFunc,
+	"title":           stdlib.TitleFunc,
+	"tobool":          stdlib.MakeToFunc(cty.Bool),
+	"tolist":          stdlib.MakeToFunc(cty.List(cty.DynamicPseudoType)),
+	"tomap":           stdlib.MakeToFunc(cty.Map(cty.DynamicPseudoType)),
+	"tonumber":        stdlib.MakeToFunc(cty.Number),
+	"toset":           stdlib.MakeToFunc(cty.Set(cty.DynamicPseudoType)),
+	"tostring":        stdlib.MakeToFunc(cty.String),
+	"trim":            stdlib.TrimFunc,
+	"trimprefix":      stdlib.TrimPrefixFunc,
+	"trimspace":       stdlib.TrimSpaceFunc,
+	"trimsuffix":      stdlib.TrimSuffixFunc,
+	"upper":           stdlib.UpperFunc,
+	"values":          stdlib.ValuesFunc,
+	"zipmap":          stdlib.ZipmapFunc,
+}
+
+var (
+	v0_13 = version.Must(version.NewVersion("0.13.0"))
+	v1_3  = version.Must(version.NewVersion("1.3.0"))
+	v1_5  = version.Must(version.NewVersion("1.5.0"))
+
+	// releaseLines are the first versions of each release line
+	// which introduced new functions, newest first
+	releaseLines = version.Collection{
+		version.Must(version.NewVersion("1.11.0")),
+		version.Must(version.NewVersion("1.9.0")),
+		version.Must(version.NewVersion("1.7.0")),
+		version.Must(version.NewVersion("1.6.0")),
+		v1_5,
+		version.Must(version.NewVersion("1.4.0")),
+		v1_3,
+		version.Must(version.NewVersion("0.15.0")),
+		version.Must(version.NewVersion("0.14.0")),
+		v0_13,
+		version.Must(version.NewVersion("0.12.0")),
+	}
+
+	// pureFunctionsSince records the first release line in which pure
+	// functions not available since 0.12 were introduced. It mirrors
+	// the function signatures in internal/funcs, which aren't used
+	// here to keep hcl-lang out of the early decoder.
+	pureFunctionsSince = map[string]*version.Version{
+		"endswith":    v1_3,
+		"parseint":    v0_13,
+		"range":       v0_13,
+		"regex":       v0_13,
+		"regexall":    v0_13,
+		"setsubtract": v0_13,
+		"startswith":  v1_3,
+		"strcontains": v1_5,
+		"trim":        v0_13,
+		"trimprefix":  v0_13,
+		"trimspace":   v0_13,
+		"trimsuffix":  v0_13,
+	}
+)
+
+// Functions returns the pure functions available in the newest release
+// line matching the given constraints. The latest functions are returned
+// if there are no constraints or none of the release lines match,
+// e.g. because a particular patch version is required.
+func Functions(constraints version.Constraints) map[string]function.Function {
+	return functionsForVersion(releaseLineForConstraints(constraints))
+}
+
+func releaseLineForConstraints(vc version.Constraints) *version.Version {
+	for _, v := range releaseLines {
+		if vc.Check(v) {
+			return v
+		}
+	}
+	return releaseLines[0]
+}
+
+func functionsForVersion(v *version.Version) map[string]function.Function {
+	fns := make(map[string]function.Function, len(pureFunctions))
+	for name, fn := range pureFunctions {
+		if since, ok := pureFunctionsSince[name]; ok && v.LessThan(since) {
+			continue
+		}
+		fns[name] = fn
+	}
+	return fns
+}
+
+// lengthFunc returns the number of elements of a collection,
+// or the number of characters of a string
+var lengthFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:             "value",
+			Type:             cty.DynamicPseudoType,
+			AllowDynamicType: true,
+		},
+	},
+	Type: func(args []cty.Value) (cty.Type, error) {
+		ty := args[0].Type()
+		if ty == cty.String || ty == cty.DynamicPseudoType {
+			return cty.Number, nil
+		}
+		return stdlib.LengthFunc.ReturnTypeForValues(args)
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if args[0].Type() == cty.String {
+			return stdlib.Strlen(args[0])
+		}
+		return stdlib.Length(args[0])
+	},
+})
+
+var startsWithFunc = stringPredicateFunc("prefix", strings.HasPrefix)
+
+var endsWithFunc = stringPredicateFunc("suffix", strings.HasSuffix)
+
+var strContainsFunc = stringPredicateFunc("substr", strings.Contains)
+
+func stringPredicateFunc(argName string, predicate func(s, arg string) bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "str",
+				Type: cty.String,
+			},
+			{
+				Name: argName,
+				Type: cty.String,
+			},
+		},
+		Type: function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.BoolVal(predicate(args[0].AsString(), args[1].AsString())), nil
+		},
+	})
+}
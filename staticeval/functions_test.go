@@ -0,0 +1,31 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package staticeval
+
+import (
+	"testing"
+
+	"github.com/opentofu/opentofu-schema/internal/funcs"
+)
+
+func TestFunctions_matchSignatures(t *testing.T) {
+	for _, v := range releaseLines {
+		signatures := funcs.ForVersion(v)
+		for name := range functionsForVersion(v) {
+			if _, ok := signatures[name]; !ok {
+				t.Errorf("%s: function %q is not available", v, name)
+			}
+		}
+		for name := range pureFunctions {
+			if _, ok := signatures[name]; !ok {
+				continue
+			}
+			if _, ok := functionsForVersion(v)[name]; !ok {
+				t.Errorf("%s: available function %q is missing", v, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package staticeval
+
+import (
+	"maps"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// WithPlaceholders returns a child of the given context, which
+// provides an unknown value for any references in the expression not
+// available in the parent, such as resources or module outputs
+func WithPlaceholders(expr hcl.Expression, evalCtx *hcl.EvalContext) *hcl.EvalContext {
+	placeholders := make(map[string]cty.Value)
+	for _, traversal := range expr.Variables() {
+		name := traversal.RootName()
+		if _, ok := evalCtx.Variables[name]; !ok {
+			placeholders[name] = cty.DynamicVal
+		}
+	}
+	if len(placeholders) == 0 {
+		return evalCtx
+	}
+
+	ctx := evalCtx.NewChild()
+	ctx.Variables = placeholders
+	return ctx
+}
+
+// ResolveLocals evaluates the given local value expressions, which may
+// reference the given variables, other locals and functions. Only locals
+// which evaluate to a wholly known value are returned.
+func ResolveLocals(exprs map[string]hcl.Expression, vars map[string]cty.Value, functions map[string]function.Function) map[string]cty.Value {
+	resolved := make(map[string]cty.Value, len(exprs))
+	if len(exprs) == 0 {
+		return resolved
+	}
+
+	remaining := make(map[string]hcl.Expression, len(exprs))
+	maps.Copy(remaining, exprs)
+
+	for len(remaining) > 0 {
+		ctx := &hcl.EvalContext{
+			Variables: map[string]cty.Value{
+				"var":   objectOrEmpty(vars),
+				"local": objectOrEmpty(resolved),
+			},
+			Functions: functions,
+		}
+
+		progressed := false
+		for name, expr := range remaining {
+			val, diags := expr.Value(ctx)
+			if diags.HasErrors() || !val.IsWhollyKnown() {
+				continue
+			}
+			resolved[name] = val
+			delete(remaining, name)
+			progressed = true
+		}
+		// Keep going until we cant progress anymore
+		if !progressed {
+			break
+		}
+	}
+
+	return resolved
+}
+
+func objectOrEmpty(m map[string]cty.Value) cty.Value {
+	if len(m) == 0 {
+		return cty.EmptyObjectVal
+	}
+	return cty.ObjectVal(m)
+}
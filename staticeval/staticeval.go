@@ -0,0 +1,196 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package staticeval evaluates variables and locals of a module
+// without planning, i.e. without any provider or remote state.
+package staticeval
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/opentofu/opentofu-schema/module"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Options represents values of variables set outside of the module,
+// in addition to their default values
+type Options struct {
+	// VarFiles are parsed .tfvars (or .tfvars.json) files in the order
+	// of precedence, i.e. values in later files override earlier ones
+	VarFiles []*hcl.File
+
+	// Environ is the environment in the "key=value" form (see os.Environ),
+	// where TF_VAR_ prefixed entries set values of variables.
+	// Values from VarFiles take precedence.
+	Environ []string
+}
+
+// Result contains values of variables and locals which are known
+// statically, keyed by their name
+type Result struct {
+	Variables map[string]cty.Value
+	Locals    map[string]cty.Value
+}
+
+// Evaluate returns the known values of variables and locals declared
+// in the given module, which was decoded from the given files.
+//
+// Variables or locals which cannot be resolved, e.g. because there is
+// no value for the variable or the local refers to resources, are
+// omitted from the result and explained by warning diagnostics.
+func Evaluate(meta *module.Meta, files map[string]*hcl.File, opts Options) (*Result, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	envValues, eDiags := envInputValues(opts.Environ, meta.Variables)
+	diags = append(diags, eDiags...)
+	fileValues, fDiags := varFileInputValues(opts.VarFiles, meta.Variables)
+	diags = append(diags, fDiags...)
+
+	knownVars := make(map[string]cty.Value, len(meta.Variables))
+	vars := make(map[string]cty.Value, len(meta.Variables))
+	for _, name := range sortedKeys(meta.Variables) {
+		v := meta.Variables[name]
+
+		input, ok := fileValues[name]
+		if !ok {
+			input, ok = envValues[name]
+		}
+		if ok {
+			val, vDiags := finalizeInputValue(name, v, input)
+			diags = append(diags, vDiags...)
+			if !vDiags.HasErrors() {
+				knownVars[name] = val
+				vars[name] = val
+				continue
+			}
+		} else if v.DefaultValue != cty.NilVal {
+			knownVars[name] = v.DefaultValue
+			vars[name] = v.DefaultValue
+			continue
+		} else {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "No value for variable",
+				Detail:   fmt.Sprintf("Variable %q has no default value and no value was set in a .tfvars file or via the %s%s environment variable.", name, envVarPrefix, name),
+				Subject:  v.DefRangePtr,
+			})
+		}
+
+		vars[name] = cty.UnknownVal(v.Type)
+	}
+
+	localAttrs := decodeLocals(meta.Filenames, files)
+	localExprs := make(map[string]hcl.Expression, len(localAttrs))
+	for name, attr := range localAttrs {
+		localExprs[name] = attr.Expr
+	}
+
+	functions := Functions(meta.CoreRequirements)
+	locals := ResolveLocals(localExprs, knownVars, functions)
+
+	allLocals := make(map[string]cty.Value, len(localAttrs))
+	for name := range localAttrs {
+		if val, ok := locals[name]; ok {
+			allLocals[name] = val
+			continue
+		}
+		allLocals[name] = cty.DynamicVal
+	}
+	evalCtx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var":   objectOrEmpty(vars),
+			"local": objectOrEmpty(allLocals),
+		},
+		Functions: functions,
+	}
+
+	for _, name := range sortedKeys(localAttrs) {
+		if _, ok := locals[name]; ok {
+			continue
+		}
+		diags = append(diags, unresolvedLocalDiagnostic(name, localAttrs[name], evalCtx))
+	}
+
+	return &Result{
+		Variables: knownVars,
+		Locals:    locals,
+	}, diags
+}
+
+// decodeLocals returns the attributes of locals blocks in the given files,
+// where attributes in override files replace those in primary files
+func decodeLocals(filenames []string, files map[string]*hcl.File) map[string]*hcl.Attribute {
+	primaryFilenames := make([]string, 0, len(filenames))
+	overrideFilenames := make([]string, 0)
+	for _, filename := range filenames {
+		if module.IsOverrideFile(filename) {
+			overrideFilenames = append(overrideFilenames, filename)
+			continue
+		}
+		primaryFilenames = append(primaryFilenames, filename)
+	}
+
+	attrs := make(map[string]*hcl.Attribute)
+	for _, filename := range append(primaryFilenames, overrideFilenames...) {
+		f, ok := files[filename]
+		if !ok || f == nil {
+			continue
+		}
+		content, _, _ := f.Body.PartialContent(localsSchema)
+		for _, block := range content.Blocks {
+			blockAttrs, _ := block.Body.JustAttributes()
+			for name, attr := range blockAttrs {
+				if _, exists := attrs[name]; module.IsOverrideFile(filename) && !exists {
+					// An override file can only override an existing local value
+					continue
+				}
+				attrs[name] = attr
+			}
+		}
+	}
+
+	return attrs
+}
+
+var localsSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type: "locals",
+		},
+	},
+}
+
+// unresolvedLocalDiagnostic explains why the given local value could not
+// be resolved, either by the error of its evaluation or its dependency
+// on unknown values
+func unresolvedLocalDiagnostic(name string, attr *hcl.Attribute, evalCtx *hcl.EvalContext) *hcl.Diagnostic {
+	detail := fmt.Sprintf("Local value %q depends on values which are not known statically, such as variables without a value or resource attributes.", name)
+
+	_, diags := attr.Expr.Value(WithPlaceholders(attr.Expr, evalCtx))
+	for _, diag := range diags {
+		if diag.Severity == hcl.DiagError {
+			detail = fmt.Sprintf("Local value %q cannot be evaluated: %s", name, diag.Detail)
+			break
+		}
+	}
+
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagWarning,
+		Summary:  "Unable to resolve local value",
+		Detail:   detail,
+		Subject:  attr.Expr.Range().Ptr(),
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
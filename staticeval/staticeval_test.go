@@ -0,0 +1,244 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package staticeval
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/opentofu/opentofu-schema/module"
+	"github.com/zclconf/go-cty-debug/ctydebug"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestEvaluate(t *testing.T) {
+	meta := &module.Meta{
+		Filenames: []string{"locals_override.tf", "main.tf"},
+		Variables: map[string]module.Variable{
+			"env": {
+				Type:       cty.String,
+				IsNullable: true,
+			},
+			"region": {
+				Type:         cty.String,
+				DefaultValue: cty.StringVal("eu-west-1"),
+				IsNullable:   true,
+			},
+			"zones": {
+				Type:         cty.List(cty.String),
+				DefaultValue: cty.ListValEmpty(cty.String),
+				IsNullable:   true,
+			},
+			"count": {
+				Type:       cty.Number,
+				IsNullable: true,
+			},
+		},
+	}
+	files := map[string]*hcl.File{
+		"main.tf": parseHCL(t, "main.tf", `
+locals {
+  name    = "${lower(var.env)}-${var.region}"
+  zone    = length(var.zones) > 0 ? var.zones[0] : "none"
+  size    = var.count * 2
+  overridden = "original"
+}
+`),
+		"locals_override.tf": parseHCL(t, "locals_override.tf", `
+locals {
+  overridden = "override"
+  ignored    = "no base"
+}
+`),
+	}
+
+	testCases := []struct {
+		name              string
+		opts              Options
+		expectedVariables map[string]cty.Value
+		expectedLocals    map[string]cty.Value
+		expectedWarnings  []string
+	}{
+		{
+			"defaults only",
+			Options{},
+			map[string]cty.Value{
+				"region": cty.StringVal("eu-west-1"),
+				"zones":  cty.ListValEmpty(cty.String),
+			},
+			map[string]cty.Value{
+				"zone":       cty.StringVal("none"),
+				"overridden": cty.StringVal("override"),
+			},
+			[]string{
+				"No value for variable",
+				"No value for variable",
+				"Unable to resolve local value",
+				"Unable to resolve local value",
+			},
+		},
+		{
+			"environment and tfvars",
+			Options{
+				Environ: []string{
+					"HOME=/root",
+					"TF_VAR_env=PROD",
+					"TF_VAR_region=us-east-1",
+					`TF_VAR_zones=["a", "b"]`,
+					"TF_VAR_undeclared=foo",
+				},
+				VarFiles: []*hcl.File{
+					parseHCL(t, "terraform.tfvars", `
+region = "eu-central-1"
+count  = 2
+`),
+					parseJSON(t, "prod.auto.tfvars.json", `{"count": "3"}`),
+				},
+			},
+			map[string]cty.Value{
+				"env":    cty.StringVal("PROD"),
+				"region": cty.StringVal("eu-central-1"),
+				"zones":  cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+				"count":  cty.NumberIntVal(3),
+			},
+			map[string]cty.Value{
+				"name":       cty.StringVal("prod-eu-central-1"),
+				"zone":       cty.StringVal("a"),
+				"size":       cty.NumberIntVal(6),
+				"overridden": cty.StringVal("override"),
+			},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, diags := Evaluate(meta, files, tc.opts)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags)
+			}
+
+			if diff := cmp.Diff(tc.expectedVariables, result.Variables, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("variables mismatch: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedLocals, result.Locals, ctydebug.CmpOptions); diff != "" {
+				t.Fatalf("locals mismatch: %s", diff)
+			}
+
+			warnings := make([]string, 0)
+			for _, diag := range diags {
+				warnings = append(warnings, diag.Summary)
+			}
+			if len(tc.expectedWarnings) == 0 {
+				tc.expectedWarnings = []string{}
+			}
+			if diff := cmp.Diff(tc.expectedWarnings, warnings); diff != "" {
+				t.Fatalf("warnings mismatch: %s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluate_invalidValues(t *testing.T) {
+	meta := &module.Meta{
+		Variables: map[string]module.Variable{
+			"count": {
+				Type:       cty.Number,
+				IsNullable: true,
+			},
+			"strict": {
+				Type:       cty.String,
+				IsNullable: false,
+			},
+			"tags": {
+				Type:       cty.Map(cty.String),
+				IsNullable: true,
+			},
+		},
+	}
+
+	_, diags := Evaluate(meta, map[string]*hcl.File{}, Options{
+		Environ: []string{"TF_VAR_tags={"},
+		VarFiles: []*hcl.File{
+			parseHCL(t, "terraform.tfvars", `
+count   = "many"
+strict  = null
+unknown = 1
+`),
+		},
+	})
+
+	expectedSummaries := []string{
+		"Invalid value for variable",
+		"Value for undeclared variable",
+		"Invalid value for variable",
+		"Required variable not set",
+		"No value for variable",
+	}
+	summaries := make([]string, 0)
+	for _, diag := range diags {
+		summaries = append(summaries, diag.Summary)
+	}
+	if diff := cmp.Diff(expectedSummaries, summaries); diff != "" {
+		t.Fatalf("diagnostics mismatch: %s", diff)
+	}
+}
+
+func TestEvaluate_unresolvedLocal(t *testing.T) {
+	meta := &module.Meta{
+		Filenames:        []string{"main.tf"},
+		CoreRequirements: version.MustConstraints(version.NewConstraint("~> 1.4.0")),
+		Variables:        map[string]module.Variable{},
+	}
+	files := map[string]*hcl.File{
+		"main.tf": parseHCL(t, "main.tf", `
+locals {
+  id       = aws_instance.web.id
+  contains = strcontains("foo", "o")
+}
+`),
+	}
+
+	result, diags := Evaluate(meta, files, Options{})
+	if len(result.Locals) != 0 {
+		t.Fatalf("expected no locals to be resolved, got %#v", result.Locals)
+	}
+
+	expectedDetails := []string{
+		`Local value "contains" cannot be evaluated: There is no function named "strcontains".`,
+		`Local value "id" depends on values which are not known statically, such as variables without a value or resource attributes.`,
+	}
+	details := make([]string, 0)
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagWarning {
+			t.Fatalf("expected warning, got %s", diag)
+		}
+		details = append(details, diag.Detail)
+	}
+	if diff := cmp.Diff(expectedDetails, details); diff != "" {
+		t.Fatalf("diagnostics mismatch: %s", diff)
+	}
+}
+
+func parseHCL(t *testing.T, filename, src string) *hcl.File {
+	f, diags := hclsyntax.ParseConfig([]byte(src), filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	return f
+}
+
+func parseJSON(t *testing.T, filename, src string) *hcl.File {
+	f, diags := json.Parse([]byte(src), filename)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	return f
+}
@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2024 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package staticeval
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/opentofu/opentofu-schema/module"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+const envVarPrefix = "TF_VAR_"
+
+// inputValue represents a value of a variable set outside of the module
+type inputValue struct {
+	Value cty.Value
+	// Source describes where the value comes from for diagnostics
+	Source string
+	// RangePtr points to the value in a .tfvars file, if any
+	RangePtr *hcl.Range
+}
+
+// envInputValues returns values of declared variables from TF_VAR_
+// prefixed environment variables. Values of variables with primitive
+// (or no) type are taken literally, others are parsed as HCL expressions.
+func envInputValues(environ []string, variables map[string]module.Variable) (map[string]inputValue, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	values := make(map[string]inputValue)
+
+	for _, entry := range environ {
+		key, raw, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(key, envVarPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, envVarPrefix)
+		v, ok := variables[name]
+		if !ok {
+			// OpenTofu ignores environment variables for undeclared
+			// variables, as they may be meant for another module
+			continue
+		}
+
+		source := fmt.Sprintf("environment variable %s", key)
+		if v.Type.IsPrimitiveType() || v.Type == cty.DynamicPseudoType || v.Type == cty.NilType {
+			values[name] = inputValue{
+				Value:  cty.StringVal(raw),
+				Source: source,
+			}
+			continue
+		}
+
+		expr, pDiags := hclsyntax.ParseExpression([]byte(raw), key, hcl.InitialPos)
+		if pDiags.HasErrors() {
+			diags = append(diags, invalidValueDiagnostic(name, source, pDiags[0].Detail, nil))
+			continue
+		}
+		val, vDiags := expr.Value(nil)
+		if vDiags.HasErrors() {
+			diags = append(diags, invalidValueDiagnostic(name, source, vDiags[0].Detail, nil))
+			continue
+		}
+		values[name] = inputValue{
+			Value:  val,
+			Source: source,
+		}
+	}
+
+	return values, diags
+}
+
+// varFileInputValues returns values of variables from the given .tfvars
+// (or .tfvars.json) files, where values in later files take precedence
+func varFileInputValues(files []*hcl.File, variables map[string]module.Variable) (map[string]inputValue, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	values := make(map[string]inputValue)
+
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		attrs, aDiags := f.Body.JustAttributes()
+		diags = append(diags, aDiags...)
+
+		names := make([]string, 0, len(attrs))
+		for name := range attrs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			attr := attrs[name]
+			if _, ok := variables[name]; !ok {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagWarning,
+					Summary:  "Value for undeclared variable",
+					Detail:   fmt.Sprintf("The module does not declare a variable named %q but a value was found in a .tfvars file.", name),
+					Subject:  attr.NameRange.Ptr(),
+				})
+				continue
+			}
+
+			val, vDiags := attr.Expr.Value(nil)
+			if vDiags.HasErrors() {
+				diags = append(diags, vDiags...)
+				continue
+			}
+			values[name] = inputValue{
+				Value:    val,
+				Source:   "a .tfvars file",
+				RangePtr: attr.Expr.Range().Ptr(),
+			}
+		}
+	}
+
+	return values, diags
+}
+
+// finalizeInputValue converts the given value to the variable's type
+// constraint, applying any defaults of optional object attributes
+func finalizeInputValue(name string, v module.Variable, input inputValue) (cty.Value, hcl.Diagnostics) {
+	val := input.Value
+	if val.IsNull() && !v.IsNullable {
+		if v.DefaultValue != cty.NilVal {
+			return v.DefaultValue, nil
+		}
+		return cty.NilVal, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Required variable not set",
+				Detail:   fmt.Sprintf("The value for variable %q set from %s is null, but the variable is not nullable.", name, input.Source),
+				Subject:  input.RangePtr,
+			},
+		}
+	}
+
+	if v.TypeDefaults != nil {
+		val = v.TypeDefaults.Apply(val)
+	}
+	if v.Type == cty.NilType {
+		return val, nil
+	}
+
+	val, err := convert.Convert(val, v.Type)
+	if err != nil {
+		return cty.NilVal, hcl.Diagnostics{
+			invalidValueDiagnostic(name, input.Source, err.Error(), input.RangePtr),
+		}
+	}
+	return val, nil
+}
+
+func invalidValueDiagnostic(name, source, detail string, rng *hcl.Range) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid value for variable",
+		Detail:   fmt.Sprintf("The value for variable %q set from %s is not valid: %s", name, source, detail),
+		Subject:  rng,
+	}
+}